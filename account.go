@@ -0,0 +1,145 @@
+package bittrex
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TotalFees returns the sum of the commissions paid on the orders of market
+// (or of all markets if market is "all") placed between from and to (inclusive).
+func (b *Bittrex) TotalFees(market string, from, to time.Time) (total decimal.Decimal, err error) {
+	orders, err := b.GetOrderHistory(market)
+	if err != nil {
+		return
+	}
+	for _, order := range orders {
+		if order.TimeStamp.Before(from) || order.TimeStamp.After(to) {
+			continue
+		}
+		total = total.Add(order.Commission)
+	}
+	return
+}
+
+// GetOrderHistoryRange returns your orders of market (or of all markets if market is "all")
+// closed between from and to (inclusive), sorted by close time (oldest first). The v1.1 API
+// does not filter the history by date, so it is fetched whole and filtered locally. Orders
+// without a close time are dated by their TimeStamp.
+func (b *Bittrex) GetOrderHistoryRange(market string, from, to time.Time) (orders []Order, err error) {
+	history, err := b.GetOrderHistory(market)
+	if err != nil {
+		return
+	}
+	for _, order := range history {
+		if closed := order.closeTime(); !closed.Before(from) && !closed.After(to) {
+			orders = append(orders, order)
+		}
+	}
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].closeTime().Before(orders[j].closeTime())
+	})
+	return
+}
+
+// TradedVolumeByMarket returns the notional volume of your orders placed between from and
+// to (inclusive), grouped by market: the sum of their Price (filled value, in the base
+// currency of the market, commissions excluded).
+func (b *Bittrex) TradedVolumeByMarket(from, to time.Time) (volumes map[string]decimal.Decimal, err error) {
+	orders, err := b.GetOrderHistory("all")
+	if err != nil {
+		return
+	}
+	volumes = make(map[string]decimal.Decimal)
+	for _, order := range orders {
+		if order.TimeStamp.Before(from) || order.TimeStamp.After(to) {
+			continue
+		}
+		volumes[order.Exchange] = volumes[order.Exchange].Add(order.Price)
+	}
+	return
+}
+
+// GetBalancesNonZero returns your balances, by currency, which are not zero or have
+// a pending amount. Like GetBalances, if some balances could not be decoded, the others
+// are returned with a MultiError describing the failures.
+func (b *Bittrex) GetBalancesNonZero() (balances map[string]Balance, err error) {
+	all, err := b.GetBalances()
+	var errs MultiError
+	if err != nil && !errors.As(err, &errs) {
+		return
+	}
+	balances = make(map[string]Balance)
+	for _, balance := range all {
+		if !balance.Balance.IsZero() || !balance.Pending.IsZero() {
+			balances[balance.Currency] = balance
+		}
+	}
+	return
+}
+
+// FindOrphanOrders returns the open orders, on all markets, whose uuid is not in knownUUIDs.
+// It is useful to detect orders placed by another process or by a previous run.
+func (b *Bittrex) FindOrphanOrders(knownUUIDs []string) (orphans []Order, err error) {
+	orders, err := b.GetOpenOrders("all")
+	if err != nil {
+		return
+	}
+	known := make(map[string]bool, len(knownUUIDs))
+	for _, uuid := range knownUUIDs {
+		known[uuid] = true
+	}
+	for _, order := range orders {
+		if !known[order.OrderUuid] {
+			orphans = append(orphans, order)
+		}
+	}
+	return
+}
+
+// CurrencyExposure returns your net exposure to each currency: the total balance
+// (which already includes the funds reserved by open orders) plus the effect the open
+// orders would have once filled. On a market BTC-LTC, an open buy of Q LTC at rate R adds
+// Q to the LTC exposure and removes Q*R from the BTC one, an open sell does the opposite.
+// Commissions are ignored. If some balances could not be decoded, the exposure is computed
+// without them and returned with the MultiError of GetBalances: the exposure to those
+// currencies is then only the effect of the open orders.
+func (b *Bittrex) CurrencyExposure() (exposure map[string]decimal.Decimal, err error) {
+	balances, err := b.GetBalances()
+	var balanceErrs MultiError
+	if err != nil && !errors.As(err, &balanceErrs) {
+		return
+	}
+	orders, err := b.GetOpenOrders("all")
+	if err != nil {
+		return
+	}
+	exposure = make(map[string]decimal.Decimal, len(balances))
+	for _, balance := range balances {
+		exposure[balance.Currency] = exposure[balance.Currency].Add(balance.Balance)
+	}
+	for _, order := range orders {
+		base, currency, err := SplitMarket(order.Exchange)
+		if err != nil {
+			return nil, fmt.Errorf("order %s: %w", order.OrderUuid, err)
+		}
+		quantity := order.QuantityRemaining
+		var value decimal.Decimal
+		if order.Limit != nil {
+			value = quantity.Mul(*order.Limit)
+		}
+		if strings.HasSuffix(order.OrderType, "SELL") {
+			quantity, value = quantity.Neg(), value.Neg()
+		}
+		exposure[currency] = exposure[currency].Add(quantity)
+		exposure[base] = exposure[base].Sub(value)
+	}
+	if len(balanceErrs) > 0 {
+		return exposure, balanceErrs
+	}
+	return
+}
@@ -1,6 +1,42 @@
 package bittrex
 
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ADDRESS_GENERATING is the message of GetDepositAddress while Bittrex generates the address
+const ADDRESS_GENERATING = "ADDRESS_GENERATING"
+
 type Address struct {
 	Currency string `json:"Currency"`
 	Address  string `json:"Address"`
 }
+
+// WaitForDepositAddress polls the deposit address of currency every pollInterval until
+// Bittrex has generated it, and returns it. While the address is being generated
+// (ADDRESS_GENERATING error, or empty address), polling goes on; other errors are returned
+// right away, as is ctx.Err() once ctx is done. ErrInvalidPollInterval is returned for a
+// pollInterval which is not positive.
+func (b *Bittrex) WaitForDepositAddress(ctx context.Context, currency string, pollInterval time.Duration) (address Address, err error) {
+	if pollInterval <= 0 {
+		return address, ErrInvalidPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		address, err = b.GetDepositAddress(currency)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.Message == ADDRESS_GENERATING {
+			err = nil
+		} else if err != nil || address.Address != "" {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return address, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
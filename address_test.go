@@ -0,0 +1,49 @@
+package bittrex
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWaitForDepositAddress(t *testing.T) {
+	calls := 0
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		calls++
+		switch calls {
+		case 1:
+			return http.StatusOK, `{"success":false,"message":"ADDRESS_GENERATING","result":null}`
+		case 2:
+			return http.StatusOK, `{"success":true,"message":"","result":{"Currency":"XRP","Address":""}}`
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":{"Currency":"XRP","Address":"rAddr"}}`
+	})
+	address, err := bt.WaitForDepositAddress(context.Background(), "XRP", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address.Address != "rAddr" || calls != 3 {
+		t.Errorf("expected rAddr after 3 calls, got %q after %d", address.Address, calls)
+	}
+}
+
+func TestWaitForDepositAddressErrors(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if req.URL.Query().Get("currency") == "BAD" {
+			return http.StatusOK, `{"success":false,"message":"INVALID_CURRENCY","result":null}`
+		}
+		return http.StatusOK, `{"success":false,"message":"ADDRESS_GENERATING","result":null}`
+	})
+	if _, err := bt.WaitForDepositAddress(context.Background(), "BAD", time.Millisecond); !IsAPIError(err) || err.Error() != "INVALID_CURRENCY" {
+		t.Errorf("expected INVALID_CURRENCY, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := bt.WaitForDepositAddress(ctx, "XRP", time.Millisecond); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if _, err := bt.WaitForDepositAddress(context.Background(), "XRP", 0); err != ErrInvalidPollInterval {
+		t.Errorf("expected ErrInvalidPollInterval, got %v", err)
+	}
+}
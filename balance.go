@@ -11,3 +11,13 @@ type Balance struct {
 	Requested     bool            `json:"Requested"`
 	Uuid          string          `json:"Uuid"`
 }
+
+// Reserved returns the part of the balance which is not available, ex: reserved by open orders
+func (b Balance) Reserved() decimal.Decimal {
+	return b.Balance.Sub(b.Available)
+}
+
+// HasFunds returns whether amount is available
+func (b Balance) HasFunds(amount decimal.Decimal) bool {
+	return b.Available.GreaterThanOrEqual(amount)
+}
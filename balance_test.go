@@ -0,0 +1,165 @@
+package bittrex
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestBalancePrecision(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":true,"message":"","result":[{"Currency":"BTC","Balance":21000000.12345678,"Available":0.10000001,"Pending":0}]}`
+	})
+	balances, err := bt.GetBalances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != 1 || balances[0].Balance.String() != "21000000.12345678" || balances[0].Available.String() != "0.10000001" {
+		t.Errorf("balance not decoded to the satoshi: %+v", balances)
+	}
+}
+
+func TestOrderValuePrecision(t *testing.T) {
+	var quantity, rate string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		quantity, rate = req.URL.Query().Get("quantity"), req.URL.Query().Get("rate")
+		return http.StatusOK, `{"success":true,"message":"","result":{"uuid":"abc"}}`
+	})
+	// 0.1 + 0.2 is 0.30000000000000004 in float64
+	q := decimal.RequireFromString("0.1").Add(decimal.RequireFromString("0.2"))
+	if _, err := bt.BuyLimit("BTC-LTC", q, decimal.RequireFromString("0.00000001")); err != nil {
+		t.Fatal(err)
+	}
+	if quantity != "0.3" || rate != "0.00000001" {
+		t.Errorf("expected quantity 0.3 and rate 0.00000001, got %s and %s", quantity, rate)
+	}
+}
+
+func TestWithdrawEscapesAddress(t *testing.T) {
+	address := "1Abc+de/f&paymentid=x y"
+	var rawQuery, sentAddress string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		rawQuery, sentAddress = req.URL.RawQuery, req.URL.Query().Get("address")
+		return http.StatusOK, `{"success":true,"message":"","result":{"uuid":"abc"}}`
+	})
+	if _, err := bt.Withdraw(address, "btc", decimal.RequireFromString("0.5")); err != nil {
+		t.Fatal(err)
+	}
+	if sentAddress != address {
+		t.Errorf("expected address %q, got %q", address, sentAddress)
+	}
+	if !strings.Contains(rawQuery, "address=1Abc%2Bde%2Ff%26paymentid%3Dx+y&") {
+		t.Errorf("address not escaped in %s", rawQuery)
+	}
+}
+
+func TestWithdrawWithPaymentID(t *testing.T) {
+	var query url.Values
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		query = req.URL.Query()
+		return http.StatusOK, `{"success":true,"message":"","result":{"uuid":"abc"}}`
+	})
+	if _, err := bt.WithdrawWithPaymentID("rAddr", "xrp", decimal.New(20, 0), "1234 &memo"); err != nil {
+		t.Fatal(err)
+	}
+	if p := query.Get("paymentid"); p != "1234 &memo" {
+		t.Errorf("expected paymentid %q, got %q", "1234 &memo", p)
+	}
+
+	if _, err := bt.Withdraw("rAddr", "xrp", decimal.New(20, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := query["paymentid"]; ok {
+		t.Errorf("expected no paymentid, got %q", query.Get("paymentid"))
+	}
+}
+
+func TestGetBalancesNonZero(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":true,"message":"","result":[
+			{"Currency":"BTC","Balance":1.5,"Available":1,"Pending":0},
+			{"Currency":"DOGE","Balance":0,"Available":0,"Pending":0},
+			{"Currency":"LTC","Balance":0,"Available":0,"Pending":2}]}`
+	})
+	balances, err := bt.GetBalancesNonZero()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != 2 || balances["DOGE"].Currency != "" {
+		t.Fatalf("expected BTC and LTC balances, got %+v", balances)
+	}
+	btc := balances["BTC"]
+	if btc.Reserved().String() != "0.5" || !btc.HasFunds(decimal.New(1, 0)) || btc.HasFunds(decimal.RequireFromString("1.01")) {
+		t.Errorf("unexpected reserved or available funds in %+v", btc)
+	}
+}
+
+func TestGetBalanceChecked(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		switch req.URL.Query().Get("currency") {
+		case "LTC":
+			return http.StatusOK, `{"success":true,"message":"","result":{"Currency":"LTC","Balance":0,"Available":0,"Pending":0,"CryptoAddress":null}}`
+		case "DOGE":
+			return http.StatusOK, `{"success":true,"message":"","result":null}`
+		}
+		return http.StatusOK, `{"success":false,"message":"INVALID_CURRENCY","result":null}`
+	})
+	if balance, found, err := bt.GetBalanceChecked("ltc"); err != nil || !found || !balance.Balance.IsZero() {
+		t.Errorf("LTC: expected a zero balance found, got %+v, %v, %v", balance, found, err)
+	}
+	if _, found, err := bt.GetBalanceChecked("doge"); err != nil || found {
+		t.Errorf("DOGE: expected no balance found, got %v, %v", found, err)
+	}
+	if _, found, err := bt.GetBalanceChecked("foo"); !errors.Is(err, ErrInvalidCurrency) || found {
+		t.Errorf("FOO: expected ErrInvalidCurrency, got %v, %v", found, err)
+	}
+}
+
+func TestGetBalancesPartial(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":true,"message":"","result":[
+			{"Currency":"BTC","Balance":1.5,"Available":1,"Pending":0},
+			{"Currency":"NEW","Balance":{"odd":true},"Available":0,"Pending":0},
+			{"Currency":"LTC","Balance":2,"Available":2,"Pending":0}]}`
+	})
+	balances, err := bt.GetBalances()
+	if len(balances) != 2 || balances[0].Currency != "BTC" || balances[1].Currency != "LTC" {
+		t.Errorf("expected BTC and LTC balances, got %+v", balances)
+	}
+	var errs MultiError
+	if !errors.As(err, &errs) || len(errs) != 1 || !strings.Contains(errs[0].Error(), "(NEW)") {
+		t.Errorf("expected the failure of NEW, got %v", err)
+	}
+}
+
+func TestGetBalancesNonZeroPartial(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if strings.HasSuffix(req.URL.Path, "/market/getopenorders") {
+			return http.StatusOK, `{"success":true,"message":"","result":[
+				{"OrderUuid":"abc","Exchange":"BTC-LTC","OrderType":"LIMIT_SELL","QuantityRemaining":1,"Limit":0.01}]}`
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":[
+			{"Currency":"BTC","Balance":1.5,"Available":1,"Pending":0},
+			{"Currency":"NEW","Balance":{"odd":true},"Available":0,"Pending":0},
+			{"Currency":"LTC","Balance":2,"Available":1,"Pending":0}]}`
+	})
+	var errs MultiError
+	balances, err := bt.GetBalancesNonZero()
+	if len(balances) != 2 || balances["BTC"].Currency != "BTC" || balances["LTC"].Currency != "LTC" {
+		t.Errorf("expected BTC and LTC balances, got %+v", balances)
+	}
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Errorf("expected the failure of NEW, got %v", err)
+	}
+	exposure, err := bt.CurrencyExposure()
+	if exposure["BTC"].String() != "1.51" || exposure["LTC"].String() != "1" {
+		t.Errorf("unexpected exposure %v", exposure)
+	}
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Errorf("expected the failure of NEW, got %v", err)
+	}
+}
@@ -6,8 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"github.com/shopspring/decimal"
+	"log"
 	"math/rand"
 	"net/http"
+	"net/url"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -15,39 +19,119 @@ import (
 const (
 	API_BASE    = "https://bittrex.com/api/" // Bittrex API endpoint
 	API_VERSION = "v1.1"
-	WS_BASE     = "socket.bittrex.com" // Bittrex WS API endpoint
-	WS_HUB      = "CoreHub"            // SignalR main hub
+	API_V2_BASE = "https://bittrex.com/Api/v2.0/" // Bittrex API v2.0 endpoint, for candles
+	API_V3_BASE = "https://api.bittrex.com/v3/"   // Bittrex API v3 endpoint, for historical candles
+	WS_BASE     = "socket.bittrex.com"            // Bittrex WS API endpoint
+	WS_HUB      = "CoreHub"                       // SignalR main hub
 )
 
-// New returns an instantiated bittrex struct
+// DEFAULT_QUOTE_SLIPPAGE_BUFFER is the default price margin of BuyMarketQuote (1%)
+var DEFAULT_QUOTE_SLIPPAGE_BUFFER = decimal.NewFromFloat(0.01)
+
+// DEFAULT_MAX_CONCURRENCY is the default number of concurrent requests of batch methods
+const DEFAULT_MAX_CONCURRENCY = 4
+
+// DEFAULT_COMMISSION_RATE is the commission charged by Bittrex on each trade (0.25%)
+var DEFAULT_COMMISSION_RATE = decimal.NewFromFloat(0.0025)
+
+// New returns a Bittrex client using the given API credentials
 func New(apiKey, apiSecret string) *Bittrex {
 	client := NewClient(apiKey, apiSecret)
-	return &Bittrex{client}
+	return newBittrex(client)
 }
 
-// NewWithCustomHttpClient returns an instantiated bittrex struct with custom http client
+// NewWithCustomHttpClient returns a Bittrex client using a custom http client
 func NewWithCustomHttpClient(apiKey, apiSecret string, httpClient *http.Client) *Bittrex {
 	client := NewClientWithCustomHttpConfig(apiKey, apiSecret, httpClient)
-	return &Bittrex{client}
+	return newBittrex(client)
 }
 
-// NewWithCustomTimeout returns an instantiated bittrex struct with custom timeout
+// NewWithCustomTimeout returns a Bittrex client with a custom request timeout
 func NewWithCustomTimeout(apiKey, apiSecret string, timeout time.Duration) *Bittrex {
 	client := NewClientWithCustomTimeout(apiKey, apiSecret, timeout)
-	return &Bittrex{client}
+	return newBittrex(client)
 }
 
-// handleErr gets JSON response from Bittrex API en deal with error
-func handleErr(r jsonResponse) error {
+// NewFromEnv returns a Bittrex client using the API credentials of the environment
+// variables BITTREX_API_KEY and BITTREX_API_SECRET, so they stay out of the source.
+// If set, BITTREX_BASE_URL is used as base URL (see SetBaseURL) and BITTREX_TIMEOUT,
+// a duration like "10s", as request timeout. An error is returned if a credential is
+// missing or the timeout is invalid.
+func NewFromEnv() (*Bittrex, error) {
+	apiKey, apiSecret := os.Getenv("BITTREX_API_KEY"), os.Getenv("BITTREX_API_SECRET")
+	if apiKey == "" || apiSecret == "" {
+		return nil, errors.New("BITTREX_API_KEY and BITTREX_API_SECRET must be set")
+	}
+	b := New(apiKey, apiSecret)
+	if timeout := os.Getenv("BITTREX_TIMEOUT"); timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid BITTREX_TIMEOUT %q", timeout)
+		}
+		b = NewWithCustomTimeout(apiKey, apiSecret, d)
+	}
+	if baseURL := os.Getenv("BITTREX_BASE_URL"); baseURL != "" {
+		b.SetBaseURL(baseURL)
+	}
+	return b, nil
+}
+
+// newBittrex wraps client in a Bittrex client with default settings
+func newBittrex(client *client) *Bittrex {
+	return &Bittrex{
+		client:              client,
+		doer:                client,
+		commissionRate:      DEFAULT_COMMISSION_RATE,
+		decoder:             jsonDecoder{},
+		maxConcurrency:      DEFAULT_MAX_CONCURRENCY,
+		quoteSlippageBuffer: DEFAULT_QUOTE_SLIPPAGE_BUFFER,
+		bracketPollInterval: BRACKET_POLL_INTERVAL,
+	}
+}
+
+// do performs a request of the v1.1 API through the doer of b. The call options apply to
+// the default doer, the client: a doer replacing it only gets the request.
+func (b *Bittrex) do(opts callOptions, method string, resource string, payload string, authNeeded bool) ([]byte, error) {
+	if c, ok := b.doer.(*client); ok {
+		return c.doWithOptions(opts, method, resource, payload, authNeeded)
+	}
+	return b.doer.do(method, resource, payload, authNeeded)
+}
+
+// handleErr gets JSON response from Bittrex API en deal with error,
+// returning an *APIError if the request failed
+func (b *Bittrex) handleErr(r jsonResponse) error {
 	if !r.Success {
-		return errors.New(r.Message)
+		if b.benignMessages[r.Message] {
+			return nil
+		}
+		return &APIError{Message: r.Message}
 	}
 	return nil
 }
 
-// bittrex represent a bittrex client
+// Bittrex is a client of Bittrex API, returned by New and its variants.
+// It can be stored in struct fields and used in function signatures; to mock it in
+// tests, declare an interface with the methods you use, which *Bittrex satisfies.
 type Bittrex struct {
-	client *client
+	client              *client
+	doer                doer // client, unless replaced by a test
+	benignMessages      map[string]bool
+	commissionRate      decimal.Decimal
+	marketCache         marketCache
+	cache               responseCache
+	roundQuantity       bool
+	minTradeSizeCheck   bool
+	strictPrecision     bool
+	heartbeatTimeout    time.Duration
+	decoder             Decoder
+	selfTradePrevention bool
+	readOnly            bool
+	idempotentCancel    bool
+	tolerantDecode      bool
+	maxConcurrency      int
+	quoteSlippageBuffer decimal.Decimal
+	bracketPollInterval time.Duration
 }
 
 // set enable/disable http request/response dump
@@ -55,179 +139,463 @@ func (c *Bittrex) SetDebug(enable bool) {
 	c.client.debug = enable
 }
 
+// SetValidateMinTradeSize enable/disable the check, by the order placing methods, of
+// the quantity against the minimum trade size of the market. An order below it fails
+// without calling the API, with an error matching ErrMinTradeRequirement with errors.Is.
+// The markets are fetched once and cached (see SetCacheTTL). Default is disabled.
+func (b *Bittrex) SetValidateMinTradeSize(enable bool) {
+	b.minTradeSizeCheck = enable
+}
+
+// SetRoundQuantity enable/disable flooring of order quantities to the market
+// quantity step (see RoundQuantityToStep) by the order placing methods.
+func (b *Bittrex) SetRoundQuantity(enable bool) {
+	b.roundQuantity = enable
+}
+
+// SetReadOnly enable/disable read only mode. In read only mode, methods placing
+// or canceling orders and withdrawing funds return ErrReadOnly without calling the API.
+func (b *Bittrex) SetReadOnly(enable bool) {
+	b.readOnly = enable
+}
+
+// SetDryRun enable/disable dry run mode. In dry run mode, the requests placing or
+// canceling orders and withdrawing funds are logged instead of being sent, and succeed
+// with a fake uuid (prefixed with "dryrun-") derived from the request. Other requests
+// are sent as usual.
+func (b *Bittrex) SetDryRun(enable bool) {
+	b.client.dryRun = enable
+}
+
+// SetSelfTradePrevention enable/disable the self trade check of BuyLimit and SellLimit.
+// When enabled, they fetch your open orders on the market first and return
+// ErrWouldSelfTrade if the new order would match one of them.
+func (b *Bittrex) SetSelfTradePrevention(enable bool) {
+	b.selfTradePrevention = enable
+}
+
+// SetHTTPClient sets the http client used to call Bittrex API, ex: to use a proxy or
+// share a tuned transport (see also NewWithCustomHttpClient). Its Timeout, if set,
+// replaces the request timeout.
+func (b *Bittrex) SetHTTPClient(httpClient *http.Client) {
+	b.client.httpClient = httpClient
+	if httpClient.Timeout > 0 {
+		b.client.httpTimeout = httpClient.Timeout
+	}
+}
+
+// SetReadTimeout sets the timeout of the requests reading data (market data, balances,
+// orders...), ex: short so that ticker polls fail fast. Zero restores the client timeout.
+// WithTimeout still overrides it for a single call.
+func (b *Bittrex) SetReadTimeout(timeout time.Duration) {
+	b.client.readTimeout = timeout
+}
+
+// SetWriteTimeout sets the timeout of the requests placing or canceling orders and moving
+// funds, ex: long so as not to give up on an order which may have been executed. Zero
+// restores the client timeout. WithTimeout still overrides it for a single call.
+func (b *Bittrex) SetWriteTimeout(timeout time.Duration) {
+	b.client.writeTimeout = timeout
+}
+
+// SetBaseURL sets the base URL of the v1.1 API, to which API_VERSION is appended,
+// ex: to use a mirror or a test server. It must end with a slash. Default is API_BASE.
+// The v2.0 and v3 calls still use API_V2_BASE and API_V3_BASE.
+func (b *Bittrex) SetBaseURL(baseURL string) {
+	b.client.baseURL = baseURL
+}
+
+// SetUserAgent sets the User-Agent header of the requests to Bittrex API, ex: to
+// identify your bot. Default is DEFAULT_USER_AGENT.
+func (b *Bittrex) SetUserAgent(userAgent string) {
+	b.client.userAgent = userAgent
+}
+
+// SetLogger sets the logger used for debug dumps and warnings (ex: an order value
+// truncated to the precision accepted by Bittrex). Default is the standard logger.
+func (b *Bittrex) SetLogger(logger *log.Logger) {
+	b.client.logger = logger
+}
+
+// SetStrictPrecision enable/disable strict precision. When enabled, order methods
+// return ErrPrecisionTruncated instead of truncating (and logging a warning) a
+// quantity or rate with more than DECIMAL_PRECISION decimals.
+func (b *Bittrex) SetStrictPrecision(enable bool) {
+	b.strictPrecision = enable
+}
+
+// SetClock sets the source of the current time. It defaults to time.Now and is meant to
+// be replaced in tests. Every reading of the current time goes through it: the nonces and
+// v3 timestamps of signed requests, liveness tracking, request metrics, Retry-After dates,
+// cache TTLs, daily withdrawal limits, trade and candle windows, order timelines and
+// stream heartbeats. Waiting does not: the rate limiter, the retry backoff and the poll
+// intervals run on real timers, which a replaced clock could not fire. As Bittrex checks
+// nonces and timestamps, a clock replaced against the real API must stay close to real time.
+func (b *Bittrex) SetClock(clock func() time.Time) {
+	b.client.clock = clock
+}
+
+// SetMaxResponseBytes sets the maximum size of a response body. Larger responses
+// are rejected with ErrResponseTooLarge. Default is DEFAULT_MAX_RESPONSE_BYTES.
+func (b *Bittrex) SetMaxResponseBytes(n int64) {
+	b.client.maxResponseBytes = n
+}
+
+// SetRateLimit limits the requests to Bittrex API to requestsPerSecond per second, with
+// bursts of up to requestsPerSecond requests. Requests over the limit wait for their turn,
+// or until the context of the call is done (see WithContext). Pass 0 to remove the limit,
+// which is the default.
+func (b *Bittrex) SetRateLimit(requestsPerSecond float64) {
+	if requestsPerSecond <= 0 {
+		b.client.limiter = nil
+		return
+	}
+	b.client.limiter = newRateLimiter(requestsPerSecond)
+}
+
+// SetRequestHook sets a function called after each HTTP request to Bittrex API with
+// the exchanged data, ex: to log the wire traffic. The API key is redacted from the
+// URL and the signature, sent in a header, is not passed. It must be safe for
+// concurrent use. Pass nil to remove it.
+func (b *Bittrex) SetRequestHook(hook RequestHook) {
+	b.client.hook = hook
+}
+
+// SetMaxConcurrency sets the maximum number of concurrent requests of batch methods
+// such as GetTickers. Default is DEFAULT_MAX_CONCURRENCY.
+func (b *Bittrex) SetMaxConcurrency(n int) {
+	if n < 1 {
+		n = 1
+	}
+	b.maxConcurrency = n
+}
+
+// SetMetricsObserver sets a function called after each HTTP request to Bittrex API
+// with its metrics. It must be safe for concurrent use. Pass nil to remove it.
+func (b *Bittrex) SetMetricsObserver(observer func(RequestMetrics)) {
+	b.client.observer = observer
+}
+
+// BytesSent returns the cumulative number of bytes (URLs and bodies) sent to Bittrex API.
+func (b *Bittrex) BytesSent() int64 {
+	return b.client.BytesSent()
+}
+
+// BytesReceived returns the cumulative number of bytes (response bodies) received from Bittrex API.
+func (b *Bittrex) BytesReceived() int64 {
+	return b.client.BytesReceived()
+}
+
+// LastSuccessfulCall returns the time of the last successful request to Bittrex API.
+// It is the zero time if no request succeeded yet.
+func (b *Bittrex) LastSuccessfulCall() time.Time {
+	return b.client.LastSuccessfulCall()
+}
+
+// LastError returns the error of the last request to Bittrex API, nil if it succeeded.
+func (b *Bittrex) LastError() error {
+	return b.client.LastError()
+}
+
+// SetBenignMessages sets the Bittrex error messages (ex: ORDER_NOT_OPEN) which
+// are treated as a success instead of being returned as an error.
+// Pass nil to restore the default behavior.
+func (b *Bittrex) SetBenignMessages(messages []string) {
+	b.benignMessages = make(map[string]bool, len(messages))
+	for _, message := range messages {
+		b.benignMessages[message] = true
+	}
+}
+
+// SetIdempotentCancel sets whether CancelOrder succeeds when the order is already
+// closed (filled or canceled), which makes cleanup loops robust against an order
+// filling while it is canceled. Default is false: ErrAlreadyClosed is returned.
+func (b *Bittrex) SetIdempotentCancel(enable bool) {
+	b.idempotentCancel = enable
+}
+
+// SetCommissionRate sets the commission rate used by fee related helpers (ex: 0.0025 for 0.25%).
+func (b *Bittrex) SetCommissionRate(rate decimal.Decimal) {
+	b.commissionRate = rate
+}
+
+// SetQuoteSlippageBuffer sets the margin, over the current ask, by which BuyMarketQuote
+// reduces the quantity it buys to absorb a price rise (ex: 0.01 for 1%).
+// Default is DEFAULT_QUOTE_SLIPPAGE_BUFFER.
+func (b *Bittrex) SetQuoteSlippageBuffer(buffer decimal.Decimal) {
+	b.quoteSlippageBuffer = buffer
+}
+
+// SetBracketPollInterval sets the interval at which the brackets placed by PlaceBracket
+// poll their orders and the ticker. A non-positive interval restores the default,
+// BRACKET_POLL_INTERVAL.
+func (b *Bittrex) SetBracketPollInterval(interval time.Duration) {
+	if interval <= 0 {
+		interval = BRACKET_POLL_INTERVAL
+	}
+	b.bracketPollInterval = interval
+}
+
+// CommissionRate returns the commission rate used by fee related helpers.
+func (b *Bittrex) CommissionRate() decimal.Decimal {
+	return b.commissionRate
+}
+
+// Do calls a resource of the v1.1 API (ex: "public/getticker?market=BTC-LTC") and returns
+// its result unparsed, once the response has been checked for errors like with the other
+// methods. It gives access to fields not modeled by this library, and to endpoints it does
+// not wrap yet. signed must be true for the market and account resources.
+// Resources placing or canceling orders and moving funds are refused by a read only client.
+func (b *Bittrex) Do(method, resource string, signed bool) (result json.RawMessage, err error) {
+	if b.readOnly && isMutating(method, resource) {
+		return nil, ErrReadOnly
+	}
+	r, err := b.doer.do(method, resource, "", signed)
+	if err != nil {
+		return
+	}
+	var response jsonResponse
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
+		return
+	}
+	if err = b.handleErr(response); err != nil {
+		return
+	}
+	return response.Result, nil
+}
+
 // GetDistribution is used to get the distribution.
 func (b *Bittrex) GetDistribution(market string) (distribution Distribution, err error) {
-	r, err := b.client.do("GET", "https://bittrex.com/Api/v2.0/pub/currency/GetBalanceDistribution?currencyName="+strings.ToUpper(market), "", false)
+	if market == "" {
+		return distribution, ErrEmptyCurrency
+	}
+	r, err := b.doer.do("GET", API_V2_BASE+"pub/currency/GetBalanceDistribution?"+url.Values{"currencyName": {strings.ToUpper(market)}}.Encode(), "", false)
 	if err != nil {
 		return
 	}
 
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
 
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &distribution)
+	err = b.decodeResult(response.Result, &distribution)
 	return
 
 }
 
 // GetCurrencies is used to get all supported currencies at Bittrex along with other meta data.
-func (b *Bittrex) GetCurrencies() (currencies []Currency, err error) {
-	r, err := b.client.do("GET", "public/getcurrencies", "", false)
+func (b *Bittrex) GetCurrencies(opts ...CallOption) (currencies []Currency, err error) {
+	r, err := b.cachedDo(newCallOptions(opts), "public/getcurrencies")
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &currencies)
+	err = b.decodeResult(response.Result, &currencies)
 	return
 }
 
 // GetMarkets is used to get the open and available trading markets at Bittrex along with other meta data.
-func (b *Bittrex) GetMarkets() (markets []Market, err error) {
-	r, err := b.client.do("GET", "public/getmarkets", "", false)
+func (b *Bittrex) GetMarkets(opts ...CallOption) (markets []Market, err error) {
+	markets, _, err = b.GetMarketsRaw(opts...)
+	return
+}
+
+// GetMarketsRaw is like GetMarkets but also returns the raw response body, ex: to archive it.
+func (b *Bittrex) GetMarketsRaw(opts ...CallOption) (markets []Market, raw json.RawMessage, err error) {
+	raw, err = b.cachedDo(newCallOptions(opts), "public/getmarkets")
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(raw, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &markets)
+	err = b.decodeResult(response.Result, &markets)
 	return
 }
 
 // GetTicker is used to get the current ticker values for a market.
-func (b *Bittrex) GetTicker(market string) (ticker Ticker, err error) {
-	r, err := b.client.do("GET", "public/getticker?market="+strings.ToUpper(market), "", false)
+func (b *Bittrex) GetTicker(market string, opts ...CallOption) (ticker Ticker, err error) {
+	if market == "" {
+		return ticker, ErrEmptyMarket
+	}
+	r, err := b.do(newCallOptions(opts), "GET", "public/getticker?"+url.Values{"market": {strings.ToUpper(market)}}.Encode(), "", false)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &ticker)
+	err = b.decodeResult(response.Result, &ticker)
 	return
 }
 
 // GetMarketSummaries is used to get the last 24 hour summary of all active exchanges
-func (b *Bittrex) GetMarketSummaries() (marketSummaries []MarketSummary, err error) {
-	r, err := b.client.do("GET", "public/getmarketsummaries", "", false)
+func (b *Bittrex) GetMarketSummaries(opts ...CallOption) (marketSummaries []MarketSummary, err error) {
+	marketSummaries, _, err = b.GetMarketSummariesRaw(opts...)
+	return
+}
+
+// GetMarketSummariesRaw is like GetMarketSummaries but also returns the raw response body, ex: to archive it.
+func (b *Bittrex) GetMarketSummariesRaw(opts ...CallOption) (marketSummaries []MarketSummary, raw json.RawMessage, err error) {
+	raw, err = b.do(newCallOptions(opts), "GET", "public/getmarketsummaries", "", false)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(raw, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &marketSummaries)
+	err = b.decodeResult(response.Result, &marketSummaries)
 	return
 }
 
 // GetMarketSummary is used to get the last 24 hour summary for a given market
-func (b *Bittrex) GetMarketSummary(market string) (marketSummary []MarketSummary, err error) {
-	r, err := b.client.do("GET", fmt.Sprintf("public/getmarketsummary?market=%s", strings.ToUpper(market)), "", false)
+func (b *Bittrex) GetMarketSummary(market string, opts ...CallOption) (marketSummary MarketSummary, err error) {
+	if market == "" {
+		return marketSummary, ErrEmptyMarket
+	}
+	r, err := b.do(newCallOptions(opts), "GET", "public/getmarketsummary?"+url.Values{"market": {strings.ToUpper(market)}}.Encode(), "", false)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &marketSummary)
-	return
+	// Bittrex returns a one element array
+	var summaries []MarketSummary
+	if err = b.decodeResult(response.Result, &summaries); err != nil {
+		return
+	}
+	if len(summaries) == 0 {
+		return marketSummary, fmt.Errorf("no summary for market %s", market)
+	}
+	return summaries[0], nil
 }
 
 // GetOrderBook is used to get retrieve the orderbook for a given market
 // market: a string literal for the market (ex: BTC-LTC)
 // cat: buy, sell or both to identify the type of orderbook to return.
-func (b *Bittrex) GetOrderBook(market, cat string) (orderBook OrderBook, err error) {
+// depth: the number of entries to return for each side, 0 for the API default.
+// The sides are truncated to depth entries even if the API returns more.
+func (b *Bittrex) GetOrderBook(market, cat string, depth int, opts ...CallOption) (orderBook OrderBook, err error) {
+	if market == "" {
+		return orderBook, ErrEmptyMarket
+	}
 	if cat != "buy" && cat != "sell" && cat != "both" {
 		cat = "both"
 	}
-	r, err := b.client.do("GET", fmt.Sprintf("public/getorderbook?market=%s&type=%s", strings.ToUpper(market), cat), "", false)
+	params := url.Values{"market": {strings.ToUpper(market)}, "type": {cat}}
+	if depth > 0 {
+		params.Set("depth", strconv.Itoa(depth))
+	}
+	resource := "public/getorderbook?" + params.Encode()
+	r, err := b.do(newCallOptions(opts), "GET", resource, "", false)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
 
+	// preallocate the sides, which the decoder fills in place
+	if depth > 0 {
+		orderBook.Buy = make([]Orderb, 0, depth)
+		orderBook.Sell = make([]Orderb, 0, depth)
+	}
 	if cat == "buy" {
-		err = json.Unmarshal(response.Result, &orderBook.Buy)
+		err = b.decodeResult(response.Result, &orderBook.Buy)
 	} else if cat == "sell" {
-		err = json.Unmarshal(response.Result, &orderBook.Sell)
+		err = b.decodeResult(response.Result, &orderBook.Sell)
 	} else {
-		err = json.Unmarshal(response.Result, &orderBook)
+		err = b.decodeResult(response.Result, &orderBook)
+	}
+	if err != nil {
+		return
 	}
 
+	// Bittrex may return more entries than requested
+	if depth > 0 && len(orderBook.Buy) > depth {
+		orderBook.Buy = orderBook.Buy[:depth:depth]
+	}
+	if depth > 0 && len(orderBook.Sell) > depth {
+		orderBook.Sell = orderBook.Sell[:depth:depth]
+	}
 	return
 }
 
 // GetOrderBookBuySell is used to get retrieve the buy or sell side of an orderbook for a given market
 // market: a string literal for the market (ex: BTC-LTC)
 // cat: buy or sell to identify the type of orderbook to return.
-func (b *Bittrex) GetOrderBookBuySell(market, cat string) (orderb []Orderb, err error) {
+func (b *Bittrex) GetOrderBookBuySell(market, cat string, opts ...CallOption) (orderb []Orderb, err error) {
+	if market == "" {
+		return orderb, ErrEmptyMarket
+	}
 	if cat != "buy" && cat != "sell" {
 		cat = "buy"
 	}
 
-	r, err := b.client.do("GET", fmt.Sprintf("public/getorderbook?market=%s&type=%s", strings.ToUpper(market), cat), "", false)
+	r, err := b.do(newCallOptions(opts), "GET", "public/getorderbook?"+url.Values{"market": {strings.ToUpper(market)}, "type": {cat}}.Encode(), "", false)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &orderb)
+	err = b.decodeResult(response.Result, &orderb)
 	return
 }
 
 // GetMarketHistory is used to retrieve the latest trades that have occured for a specific market.
 // market a string literal for the market (ex: BTC-LTC)
-func (b *Bittrex) GetMarketHistory(market string) (trades []Trade, err error) {
-	r, err := b.client.do("GET", fmt.Sprintf("public/getmarkethistory?market=%s", strings.ToUpper(market)), "", false)
+func (b *Bittrex) GetMarketHistory(market string, opts ...CallOption) (trades []Trade, err error) {
+	if market == "" {
+		return trades, ErrEmptyMarket
+	}
+	r, err := b.do(newCallOptions(opts), "GET", "public/getmarkethistory?"+url.Values{"market": {strings.ToUpper(market)}}.Encode(), "", false)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &trades)
+	err = b.decodeResult(response.Result, &trades)
 	return
 }
 
@@ -235,131 +603,350 @@ func (b *Bittrex) GetMarketHistory(market string) (trades []Trade, err error) {
 
 // BuyLimit is used to place a limited buy order in a specific market.
 func (b *Bittrex) BuyLimit(market string, quantity, rate decimal.Decimal) (uuid string, err error) {
-	r, err := b.client.do("GET", fmt.Sprintf("market/buylimit?market=%s&quantity=%s&rate=%s", market, quantity, rate), "", true)
+	order, err := b.BuyLimitOrder(market, quantity, rate)
+	return order.Uuid, err
+}
+
+// BuyLimitOrder places a limited buy order in a specific market as BuyLimit does, and
+// returns the order result, with the quantity and rate as sent after rounding.
+func (b *Bittrex) BuyLimitOrder(market string, quantity, rate decimal.Decimal) (order OrderResult, err error) {
+	if b.readOnly {
+		return order, ErrReadOnly
+	}
+	if market == "" {
+		return order, ErrEmptyMarket
+	}
+	if b.roundQuantity {
+		if quantity, err = b.RoundQuantityToStep(market, quantity); err != nil {
+			return
+		}
+	}
+	if b.minTradeSizeCheck {
+		if err = b.checkMinTradeSize(market, quantity); err != nil {
+			return
+		}
+	}
+	if b.selfTradePrevention {
+		if err = b.checkSelfTrade(market, BUY, rate); err != nil {
+			return
+		}
+	}
+	q, err := b.formatOrderValue("quantity", quantity)
+	if err != nil {
+		return
+	}
+	rt, err := b.formatOrderValue("rate", rate)
+	if err != nil {
+		return
+	}
+	r, err := b.doer.do("GET", "market/buylimit?"+url.Values{"market": {market}, "quantity": {q}, "rate": {rt}}.Encode(), "", true)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	var u Uuid
-	err = json.Unmarshal(response.Result, &u)
-	uuid = u.Id
+	if isNullResult(response.Result) {
+		return order, ErrNullResult
+	}
+	if err = b.decodeResult(response.Result, &order); err != nil {
+		return
+	}
+	order.Market, order.Side = market, BUY
+	order.Quantity, _ = decimal.NewFromString(q)
+	order.Rate, _ = decimal.NewFromString(rt)
+	order.Raw = response.Result
 	return
 }
 
 // SellLimit is used to place a limited sell order in a specific market.
 func (b *Bittrex) SellLimit(market string, quantity, rate decimal.Decimal) (uuid string, err error) {
-	r, err := b.client.do("GET", fmt.Sprintf("market/selllimit?market=%s&quantity=%s&rate=%s", market, quantity, rate), "", true)
+	order, err := b.SellLimitOrder(market, quantity, rate)
+	return order.Uuid, err
+}
+
+// SellLimitOrder places a limited sell order in a specific market as SellLimit does, and
+// returns the order result, with the quantity and rate as sent after rounding.
+func (b *Bittrex) SellLimitOrder(market string, quantity, rate decimal.Decimal) (order OrderResult, err error) {
+	if b.readOnly {
+		return order, ErrReadOnly
+	}
+	if market == "" {
+		return order, ErrEmptyMarket
+	}
+	if b.roundQuantity {
+		if quantity, err = b.RoundQuantityToStep(market, quantity); err != nil {
+			return
+		}
+	}
+	if b.minTradeSizeCheck {
+		if err = b.checkMinTradeSize(market, quantity); err != nil {
+			return
+		}
+	}
+	if b.selfTradePrevention {
+		if err = b.checkSelfTrade(market, SELL, rate); err != nil {
+			return
+		}
+	}
+	q, err := b.formatOrderValue("quantity", quantity)
+	if err != nil {
+		return
+	}
+	rt, err := b.formatOrderValue("rate", rate)
+	if err != nil {
+		return
+	}
+	r, err := b.doer.do("GET", "market/selllimit?"+url.Values{"market": {market}, "quantity": {q}, "rate": {rt}}.Encode(), "", true)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
+	if isNullResult(response.Result) {
+		return order, ErrNullResult
+	}
+	if err = b.decodeResult(response.Result, &order); err != nil {
+		return
+	}
+	order.Market, order.Side = market, SELL
+	order.Quantity, _ = decimal.NewFromString(q)
+	order.Rate, _ = decimal.NewFromString(rt)
+	order.Raw = response.Result
+	return
+}
+
+// BuyMarket is used to place a buy order at market price in a specific market.
+func (b *Bittrex) BuyMarket(market string, quantity decimal.Decimal) (uuid string, err error) {
+	if b.readOnly {
+		return "", ErrReadOnly
+	}
+	if market == "" {
+		return "", ErrEmptyMarket
+	}
+	if b.roundQuantity {
+		if quantity, err = b.RoundQuantityToStep(market, quantity); err != nil {
+			return
+		}
+	}
+	if b.minTradeSizeCheck {
+		if err = b.checkMinTradeSize(market, quantity); err != nil {
+			return
+		}
+	}
+	q, err := b.formatOrderValue("quantity", quantity)
+	if err != nil {
+		return
+	}
+	r, err := b.doer.do("GET", "market/buymarket?"+url.Values{"market": {market}, "quantity": {q}}.Encode(), "", true)
+	if err != nil {
+		return
+	}
+	var response jsonResponse
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
+		return
+	}
+	if err = b.handleErr(response); err != nil {
+		return
+	}
+	if isNullResult(response.Result) {
+		return "", ErrNullResult
+	}
 	var u Uuid
-	err = json.Unmarshal(response.Result, &u)
+	err = b.decodeResult(response.Result, &u)
+	uuid = u.Id
+	return
+}
+
+// SellMarket is used to place a sell order at market price in a specific market.
+func (b *Bittrex) SellMarket(market string, quantity decimal.Decimal) (uuid string, err error) {
+	if b.readOnly {
+		return "", ErrReadOnly
+	}
+	if market == "" {
+		return "", ErrEmptyMarket
+	}
+	if b.roundQuantity {
+		if quantity, err = b.RoundQuantityToStep(market, quantity); err != nil {
+			return
+		}
+	}
+	if b.minTradeSizeCheck {
+		if err = b.checkMinTradeSize(market, quantity); err != nil {
+			return
+		}
+	}
+	q, err := b.formatOrderValue("quantity", quantity)
+	if err != nil {
+		return
+	}
+	r, err := b.doer.do("GET", "market/sellmarket?"+url.Values{"market": {market}, "quantity": {q}}.Encode(), "", true)
+	if err != nil {
+		return
+	}
+	var response jsonResponse
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
+		return
+	}
+	if err = b.handleErr(response); err != nil {
+		return
+	}
+	if isNullResult(response.Result) {
+		return "", ErrNullResult
+	}
+	var u Uuid
+	err = b.decodeResult(response.Result, &u)
 	uuid = u.Id
 	return
 }
 
 // CancelOrder is used to cancel a buy or sell order.
+// If the order is already filled or canceled, Bittrex answers ORDER_NOT_OPEN (or
+// INVALID_ORDER for an order closed long ago) and an error wrapping both ErrAlreadyClosed
+// and the *APIError is returned, or nil if idempotent cancel is enabled (see
+// SetIdempotentCancel) or the message is benign (see SetBenignMessages).
 func (b *Bittrex) CancelOrder(orderID string) (err error) {
-	r, err := b.client.do("GET", "market/cancel?uuid="+orderID, "", true)
+	if b.readOnly {
+		return ErrReadOnly
+	}
+	r, err := b.doer.do("GET", "market/cancel?"+url.Values{"uuid": {orderID}}.Encode(), "", true)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	err = handleErr(response)
+	if err = b.handleErr(response); err != nil && alreadyClosedMessages[response.Message] {
+		if b.idempotentCancel {
+			return nil
+		}
+		return fmt.Errorf("%w: %w", ErrAlreadyClosed, err)
+	}
 	return
 }
 
 // GetOpenOrders returns orders that you currently have opened.
-// If market is set to "all", GetOpenOrders return all orders
+// If market is set to "all", GetOpenOrders return all orders, of every market, in a single call:
+// the market parameter is omitted from the request, and the v1.1 API does not paginate the result.
 // If market is set to a specific order, GetOpenOrders return orders for this market
-func (b *Bittrex) GetOpenOrders(market string) (openOrders []Order, err error) {
+func (b *Bittrex) GetOpenOrders(market string, opts ...CallOption) (openOrders []Order, err error) {
 	resource := "market/getopenorders"
 	if market != "all" {
-		resource += "?market=" + strings.ToUpper(market)
+		resource += "?" + url.Values{"market": {strings.ToUpper(market)}}.Encode()
 	}
-	r, err := b.client.do("GET", resource, "", true)
+	r, err := b.do(newCallOptions(opts), "GET", resource, "", true)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &openOrders)
+	err = b.decodeResult(response.Result, &openOrders)
 	return
 }
 
 // Account
 
-// GetBalances is used to retrieve all balances from your account
-func (b *Bittrex) GetBalances() (balances []Balance, err error) {
-	r, err := b.client.do("GET", "account/getbalances", "", true)
+// GetBalances is used to retrieve all balances from your account.
+// Balances which cannot be decoded are skipped: the others are returned along with
+// a MultiError of the decoding failures.
+func (b *Bittrex) GetBalances(opts ...CallOption) (balances []Balance, err error) {
+	r, err := b.do(newCallOptions(opts), "GET", "account/getbalances", "", true)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &balances)
-	return
+	// decode entry by entry, so an entry of an unexpected shape does not hide the others
+	var entries []json.RawMessage
+	if err = b.decodeResult(response.Result, &entries); err != nil {
+		return
+	}
+	var errs MultiError
+	for i, entry := range entries {
+		var balance Balance
+		if err := b.decoder.Unmarshal(entry, &balance); err != nil {
+			var currency struct{ Currency string }
+			json.Unmarshal(entry, &currency)
+			errs = append(errs, fmt.Errorf("balance %d (%s): %w", i, currency.Currency, err))
+			continue
+		}
+		balances = append(balances, balance)
+	}
+	if len(errs) > 0 {
+		return balances, errs
+	}
+	return balances, nil
 }
 
 // Getbalance is used to retrieve the balance from your account for a specific currency.
 // currency: a string literal for the currency (ex: LTC)
 func (b *Bittrex) GetBalance(currency string) (balance Balance, err error) {
-	r, err := b.client.do("GET", "account/getbalance?currency="+strings.ToUpper(currency), "", true)
+	if currency == "" {
+		return balance, ErrEmptyCurrency
+	}
+	r, err := b.doer.do("GET", "account/getbalance?"+url.Values{"currency": {strings.ToUpper(currency)}}.Encode(), "", true)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &balance)
+	err = b.decodeResult(response.Result, &balance)
 	return
 }
 
+// GetBalanceChecked is like GetBalance but tells an unknown currency from a zero balance:
+// found is false if Bittrex returns no balance, or one without currency, for currency.
+// A currency Bittrex rejects returns an error matching ErrInvalidCurrency with errors.Is.
+func (b *Bittrex) GetBalanceChecked(currency string) (balance Balance, found bool, err error) {
+	if balance, err = b.GetBalance(currency); err != nil {
+		return
+	}
+	return balance, balance.Currency != "", nil
+}
+
 // GetDepositAddress is sed to generate or retrieve an address for a specific currency.
 // currency a string literal for the currency (ie. BTC)
 func (b *Bittrex) GetDepositAddress(currency string) (address Address, err error) {
-	r, err := b.client.do("GET", "account/getdepositaddress?currency="+strings.ToUpper(currency), "", true)
+	if currency == "" {
+		return address, ErrEmptyCurrency
+	}
+	r, err := b.doer.do("GET", "account/getdepositaddress?"+url.Values{"currency": {strings.ToUpper(currency)}}.Encode(), "", true)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &address)
+	err = b.decodeResult(response.Result, &address)
 	return
 }
 
@@ -367,20 +954,52 @@ func (b *Bittrex) GetDepositAddress(currency string) (address Address, err error
 // address string the address where to send the funds.
 // currency string literal for the currency (ie. BTC)
 // quantity decimal.Decimal the quantity of coins to withdraw
+// For currencies requiring a destination tag, payment id or memo (ie. XRP, XMR, XLM), use WithdrawWithPaymentID.
 func (b *Bittrex) Withdraw(address, currency string, quantity decimal.Decimal) (withdrawUuid string, err error) {
-	r, err := b.client.do("GET", fmt.Sprintf("account/withdraw?currency=%s&quantity=%s&address=%s", strings.ToUpper(currency), quantity, address), "", true)
+	return b.WithdrawWithPaymentID(address, currency, quantity, "")
+}
+
+// WithdrawWithPaymentID is like Withdraw, for currencies requiring a destination tag,
+// payment id or memo along with the address (ie. XRP, XMR, XLM).
+// paymentID string the tag, payment id or memo of the destination. It is not sent if empty.
+func (b *Bittrex) WithdrawWithPaymentID(address, currency string, quantity decimal.Decimal, paymentID string) (withdrawUuid string, err error) {
+	if b.readOnly {
+		return "", ErrReadOnly
+	}
+	if currency == "" {
+		return "", ErrEmptyCurrency
+	}
+	if address == "" {
+		return "", ErrEmptyAddress
+	}
+	// also rejects quantities truncated to zero by the precision of Bittrex
+	if !quantity.Truncate(DECIMAL_PRECISION).IsPositive() {
+		return "", fmt.Errorf("%w: %s", ErrInvalidQuantity, quantity)
+	}
+	q, err := b.formatOrderValue("quantity", quantity)
+	if err != nil {
+		return
+	}
+	params := url.Values{"currency": {strings.ToUpper(currency)}, "quantity": {q}, "address": {address}}
+	if paymentID != "" {
+		params.Set("paymentid", paymentID)
+	}
+	r, err := b.doer.do("GET", "account/withdraw?"+params.Encode(), "", true)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
+	if isNullResult(response.Result) {
+		return "", ErrNullResult
+	}
 	var u Uuid
-	err = json.Unmarshal(response.Result, &u)
+	err = b.decodeResult(response.Result, &u)
 	withdrawUuid = u.Id
 	return
 }
@@ -388,22 +1007,28 @@ func (b *Bittrex) Withdraw(address, currency string, quantity decimal.Decimal) (
 // GetOrderHistory used to retrieve your order history.
 // market string literal for the market (ie. BTC-LTC). If set to "all", will return for all market
 func (b *Bittrex) GetOrderHistory(market string) (orders []Order, err error) {
+	orders, _, err = b.GetOrderHistoryRaw(market)
+	return
+}
+
+// GetOrderHistoryRaw is like GetOrderHistory but also returns the raw response body, ex: to archive it.
+func (b *Bittrex) GetOrderHistoryRaw(market string) (orders []Order, raw json.RawMessage, err error) {
 	resource := "account/getorderhistory"
 	if market != "all" {
-		resource += "?market=" + market
+		resource += "?" + url.Values{"market": {market}}.Encode()
 	}
-	r, err := b.client.do("GET", resource, "", true)
+	raw, err = b.doer.do("GET", resource, "", true)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(raw, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &orders)
+	err = b.decodeResult(response.Result, &orders)
 	return
 }
 
@@ -412,20 +1037,20 @@ func (b *Bittrex) GetOrderHistory(market string) (orders []Order, err error) {
 func (b *Bittrex) GetWithdrawalHistory(currency string) (withdrawals []Withdrawal, err error) {
 	resource := "account/getwithdrawalhistory"
 	if currency != "all" {
-		resource += "?currency=" + currency
+		resource += "?" + url.Values{"currency": {currency}}.Encode()
 	}
-	r, err := b.client.do("GET", resource, "", true)
+	r, err := b.doer.do("GET", resource, "", true)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &withdrawals)
+	err = b.decodeResult(response.Result, &withdrawals)
 	return
 }
 
@@ -434,105 +1059,115 @@ func (b *Bittrex) GetWithdrawalHistory(currency string) (withdrawals []Withdrawa
 func (b *Bittrex) GetDepositHistory(currency string) (deposits []Deposit, err error) {
 	resource := "account/getdeposithistory"
 	if currency != "all" {
-		resource += "?currency=" + currency
+		resource += "?" + url.Values{"currency": {currency}}.Encode()
 	}
-	r, err := b.client.do("GET", resource, "", true)
+	r, err := b.doer.do("GET", resource, "", true)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = handleErr(response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &deposits)
+	err = b.decodeResult(response.Result, &deposits)
 	return
 }
 
 func (b *Bittrex) GetOrder(order_uuid string) (order Order2, err error) {
 
-	resource := "account/getorder?uuid=" + order_uuid
+	resource := "account/getorder?" + url.Values{"uuid": {order_uuid}}.Encode()
 
-	r, err := b.client.do("GET", resource, "", true)
+	r, err := b.doer.do("GET", resource, "", true)
 	if err != nil {
 		return
 	}
 	var response jsonResponse
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.decoder.Unmarshal(r, &response); err != nil {
 		return
 	}
-	if err = json.Unmarshal(r, &response); err != nil {
+	if err = b.handleErr(response); err != nil {
 		return
 	}
-	err = json.Unmarshal(response.Result, &order)
+	err = b.decodeResult(response.Result, &order)
 	return
 }
 
 // GetTicks is used to get ticks history values for a market.
 // Interval can be -> ["oneMin", "fiveMin", "thirtyMin", "hour", "day"]
 func (b *Bittrex) GetTicks(market string, interval string) ([]Candle, error) {
+	if market == "" {
+		return nil, ErrEmptyMarket
+	}
 	_, ok := CANDLE_INTERVALS[interval]
 	if !ok {
-		return nil, errors.New("wrong interval")
+		return nil, fmt.Errorf("wrong interval %s", interval)
 	}
 
 	endpoint := fmt.Sprintf(
-		"https://bittrex.com/Api/v2.0/pub/market/GetTicks?tickInterval=%s&marketName=%s&_=%d",
-		interval, strings.ToUpper(market), rand.Int(),
+		"%spub/market/GetTicks?%s", API_V2_BASE,
+		url.Values{"tickInterval": {interval}, "marketName": {strings.ToUpper(market)}, "_": {strconv.Itoa(rand.Int())}}.Encode(),
 	)
-	r, err := b.client.do("GET", endpoint, "", false)
+	r, err := b.doer.do("GET", endpoint, "", false)
 	if err != nil {
 		return nil, fmt.Errorf("could not get market ticks: %v", err)
 	}
 
 	var response jsonResponse
-	if err := json.Unmarshal(r, &response); err != nil {
+	if err := b.decoder.Unmarshal(r, &response); err != nil {
 		return nil, err
 	}
 
-	if err := handleErr(response); err != nil {
+	if err := b.handleErr(response); err != nil {
 		return nil, err
 	}
 	var candles []Candle
 
-	if err := json.Unmarshal(response.Result, &candles); err != nil {
+	if err := b.decodeResult(response.Result, &candles); err != nil {
 		return nil, fmt.Errorf("could not unmarshal candles: %v", err)
 	}
 
 	return candles, nil
 }
 
-// GetLatestTick returns array with a single element latest candle object
-func (b *Bittrex) GetLatestTick(market string, interval string) ([]Candle, error) {
+// GetLatestTick returns the latest candle of market for interval (see GetTicks for the intervals).
+func (b *Bittrex) GetLatestTick(market string, interval string) (Candle, error) {
+	if market == "" {
+		return Candle{}, ErrEmptyMarket
+	}
 	_, ok := CANDLE_INTERVALS[interval]
 	if !ok {
-		return nil, errors.New("wrong interval")
+		return Candle{}, fmt.Errorf("wrong interval %s", interval)
 	}
 
 	endpoint := fmt.Sprintf(
-		"https://bittrex.com/Api/v2.0/pub/market/GetLatestTick?tickInterval=%s&marketName=%s&_=%d",
-		interval, strings.ToUpper(market), rand.Int(),
+		"%spub/market/GetLatestTick?%s", API_V2_BASE,
+		url.Values{"tickInterval": {interval}, "marketName": {strings.ToUpper(market)}, "_": {strconv.Itoa(rand.Int())}}.Encode(),
 	)
-	r, err := b.client.do("GET", endpoint, "", false)
+	r, err := b.doer.do("GET", endpoint, "", false)
 	if err != nil {
-		return nil, fmt.Errorf("could not get market ticks: %v", err)
+		return Candle{}, fmt.Errorf("could not get market ticks: %v", err)
 	}
 
 	var response jsonResponse
-	if err := json.Unmarshal(r, &response); err != nil {
-		return nil, err
+	if err := b.decoder.Unmarshal(r, &response); err != nil {
+		return Candle{}, err
 	}
 
-	if err := handleErr(response); err != nil {
-		return nil, err
+	if err := b.handleErr(response); err != nil {
+		return Candle{}, err
 	}
+	// Bittrex returns a one element array
 	var candles []Candle
 
-	if err := json.Unmarshal(response.Result, &candles); err != nil {
-		return nil, fmt.Errorf("could not unmarshal candles: %v", err)
+	if err := b.decodeResult(response.Result, &candles); err != nil {
+		return Candle{}, fmt.Errorf("could not unmarshal candles: %v", err)
+	}
+	if len(candles) == 0 {
+		return Candle{}, fmt.Errorf("no candle for market %s", market)
 	}
 
-	return candles, nil
+	return candles[0], nil
 }
@@ -0,0 +1,199 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// BRACKET_POLL_INTERVAL is the default interval at which a bracket polls its orders and the ticker
+const BRACKET_POLL_INTERVAL = 5 * time.Second
+
+// BracketHandle controls a bracket placed by PlaceBracket
+type BracketHandle struct {
+	*bracket
+}
+
+type bracket struct {
+	b            *Bittrex
+	pollInterval time.Duration
+	cancel       context.CancelFunc
+	done         chan struct{}
+
+	mu        sync.Mutex
+	entryUuid string
+	resting   map[string]bool // uuids of the bracket orders which may still be open
+	err       error
+}
+
+// PlaceBracket places an entry limit order and, once it is filled, protects the
+// position with a take profit and a stop loss on the other side.
+// The take profit is a limit order placed at targetRate. Bittrex v1.1 having no
+// stop orders, the stop loss is handled client side: when the last price reaches
+// stopRate, the take profit is canceled and a market order closes what it left.
+// If the entry is canceled after a partial fill, the filled quantity is protected.
+// This runs in a background goroutine, polling with WaitForFill every bracket poll
+// interval (see SetBracketPollInterval), until
+// the take profit is closed, the stop loss order is placed, or ctx is done.
+// The polls failing with an error which is not an *APIError (ex: network error)
+// are retried at the next interval.
+func (b *Bittrex) PlaceBracket(ctx context.Context, market string, side OrderSide, quantity, entryRate, stopRate, targetRate decimal.Decimal) (BracketHandle, error) {
+	entryUuid, err := b.placeLimit(market, side, quantity, entryRate)
+	if err != nil {
+		return BracketHandle{}, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	br := &bracket{
+		b:            b,
+		pollInterval: b.bracketPollInterval,
+		cancel:       cancel,
+		done:         make(chan struct{}),
+		entryUuid:    entryUuid,
+		resting:      map[string]bool{entryUuid: true},
+	}
+	go func() {
+		defer close(br.done)
+		br.setErr(br.run(ctx, market, side, stopRate, targetRate))
+	}()
+	return BracketHandle{br}, nil
+}
+
+// EntryUuid returns the uuid of the entry order
+func (br *bracket) EntryUuid() string {
+	return br.entryUuid
+}
+
+// Done returns a channel closed when the bracket goroutine is over
+func (br *bracket) Done() <-chan struct{} {
+	return br.done
+}
+
+// Err returns the error which ended the bracket goroutine, nil if it ended normally or is still running
+func (br *bracket) Err() error {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	return br.err
+}
+
+// Cancel stops the bracket goroutine and cancels the bracket orders still open.
+// The orders which closed meanwhile are skipped.
+func (br *bracket) Cancel() error {
+	br.cancel()
+	<-br.done
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	var errs MultiError
+	for uuid := range br.resting {
+		if err := br.b.CancelOrder(uuid); err != nil && !errors.Is(err, ErrAlreadyClosed) {
+			errs = append(errs, err)
+			continue
+		}
+		delete(br.resting, uuid)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (br *bracket) setErr(err error) {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	br.err = err
+}
+
+func (br *bracket) setResting(uuid string, resting bool) {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	if resting {
+		br.resting[uuid] = true
+	} else {
+		delete(br.resting, uuid)
+	}
+}
+
+func (br *bracket) run(ctx context.Context, market string, side OrderSide, stopRate, targetRate decimal.Decimal) error {
+	b := br.b
+	// a partially filled entry, canceled, protects the quantity filled
+	entry, err := br.waitForFill(ctx, br.entryUuid)
+	if err != nil && err != ErrOrderCanceled {
+		return err
+	}
+	br.setResting(br.entryUuid, false)
+	quantity := entry.Quantity.Sub(entry.QuantityRemaining)
+	if !quantity.IsPositive() {
+		return ErrOrderCanceled
+	}
+
+	exitSide := SELL
+	if side == SELL {
+		exitSide = BUY
+	}
+	targetUuid, err := b.placeLimit(market, exitSide, quantity, targetRate)
+	if err != nil {
+		return err
+	}
+	br.setResting(targetUuid, true)
+
+	ticker := time.NewTicker(br.pollInterval)
+	defer ticker.Stop()
+	for {
+		target, err := b.GetOrder(targetUuid)
+		if err == nil && !target.IsOpen {
+			br.setResting(targetUuid, false)
+			return nil
+		}
+		var t Ticker
+		if err == nil {
+			t, err = b.GetTicker(market)
+		}
+		if err == nil && (side == BUY && t.Last.LessThanOrEqual(stopRate) || side == SELL && t.Last.GreaterThanOrEqual(stopRate)) {
+			break
+		}
+		if err != nil && IsAPIError(err) {
+			return err
+		}
+		// the other errors are transient, polled again at the next interval
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	// an already closed take profit filled meanwhile, like one partly filled before the cancel
+	if err = b.CancelOrder(targetUuid); err != nil && !errors.Is(err, ErrAlreadyClosed) {
+		return err
+	}
+	// read what the take profit left once closed
+	target, err := br.waitForFill(ctx, targetUuid)
+	if err != nil && err != ErrOrderCanceled {
+		return err
+	}
+	br.setResting(targetUuid, false)
+	if !target.QuantityRemaining.IsPositive() {
+		return nil
+	}
+	// a market order fills at once, so it is not recorded as resting
+	_, err = b.placeMarket(market, exitSide, target.QuantityRemaining)
+	return err
+}
+
+// waitForFill is WaitForFill at the poll interval of the bracket, retrying the errors which
+// are not *APIError (ex: network error) at the next interval.
+func (br *bracket) waitForFill(ctx context.Context, uuid string) (order Order2, err error) {
+	for {
+		order, err = br.b.WaitForFill(ctx, uuid, br.pollInterval)
+		if err == nil || err == ErrOrderCanceled || IsAPIError(err) || ctx.Err() != nil {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return order, ctx.Err()
+		case <-time.After(br.pollInterval):
+		}
+	}
+}
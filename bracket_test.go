@@ -0,0 +1,154 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// bracketExchange is a scripted exchange for PlaceBracket: the states of each order, as
+// returned by getorder, are consumed in turn, the last one repeating.
+type bracketExchange struct {
+	mu       sync.Mutex
+	orders   map[string][]string // uuid -> Order2 JSON states
+	last     string              // last price of the ticker
+	cancel   string              // response of cancel
+	requests []string            // placed orders and cancels
+}
+
+func (e *bracketExchange) handle(req *http.Request) (int, string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	q := req.URL.Query()
+	path := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+	switch path {
+	case "getorder":
+		states := e.orders[q.Get("uuid")]
+		if len(states) == 0 {
+			return http.StatusInternalServerError, "unexpected order"
+		}
+		state := states[0]
+		if len(states) > 1 {
+			e.orders[q.Get("uuid")] = states[1:]
+		}
+		if state == "500" {
+			return http.StatusInternalServerError, "transient"
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":` + state + `}`
+	case "getticker":
+		return http.StatusOK, `{"success":true,"message":"","result":{"Bid":1,"Ask":1,"Last":` + e.last + `}}`
+	case "cancel":
+		e.requests = append(e.requests, "cancel "+q.Get("uuid"))
+		return http.StatusOK, e.cancel
+	}
+	e.requests = append(e.requests, fmt.Sprintf("%s %s@%s", path, q.Get("quantity"), q.Get("rate")))
+	return http.StatusOK, `{"success":true,"message":"","result":{"uuid":"` + path + `"}}`
+}
+
+func orderState(open bool, quantity, remaining string) string {
+	return fmt.Sprintf(`{"OrderUuid":"x","Quantity":%s,"QuantityRemaining":%s,"IsOpen":%t}`, quantity, remaining, open)
+}
+
+func runBracket(t *testing.T, e *bracketExchange) (BracketHandle, error) {
+	bt := newTestBittrex(e.handle)
+	bt.SetBracketPollInterval(time.Millisecond)
+	d := decimal.RequireFromString
+	h, err := bt.PlaceBracket(context.Background(), "BTC-LTC", BUY, d("2"), d("0.01"), d("0.009"), d("0.012"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-h.Done():
+	case <-time.After(time.Second):
+		h.Cancel()
+		t.Fatal("bracket still running")
+	}
+	return h, h.Err()
+}
+
+func TestBracketTakeProfit(t *testing.T) {
+	e := &bracketExchange{last: "0.011", orders: map[string][]string{
+		// transient errors are retried
+		"buylimit":  {orderState(true, "2", "2"), "500", orderState(false, "2", "0")},
+		"selllimit": {orderState(true, "2", "2"), "500", orderState(false, "2", "0")},
+	}}
+	if _, err := runBracket(t, e); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(e.requests) != "[buylimit 2@0.01 selllimit 2@0.012]" {
+		t.Errorf("unexpected requests %v", e.requests)
+	}
+}
+
+func TestBracketStopLoss(t *testing.T) {
+	// the entry is canceled after a partial fill of 1.5, which the take profit fills
+	// 0.5 of before being canceled: the stop closes the remaining 1 at market
+	e := &bracketExchange{last: "0.009", cancel: `{"success":true,"message":"","result":null}`, orders: map[string][]string{
+		"buylimit":  {orderState(false, "2", "0.5")},
+		"selllimit": {orderState(true, "1.5", "1.5"), orderState(false, "1.5", "1")},
+	}}
+	h, err := runBracket(t, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(e.requests) != "[buylimit 2@0.01 selllimit 1.5@0.012 cancel selllimit sellmarket 1@]" {
+		t.Errorf("unexpected requests %v", e.requests)
+	}
+	// the stop filled at market: nothing is left to cancel
+	if err = h.Cancel(); err != nil || len(e.requests) != 4 {
+		t.Errorf("expected nothing canceled, got %v and requests %v", err, e.requests)
+	}
+}
+
+func TestBracketStopLossTargetFilled(t *testing.T) {
+	// the take profit filled before being canceled: no stop is placed
+	e := &bracketExchange{last: "0.009", cancel: `{"success":false,"message":"ORDER_NOT_OPEN","result":null}`, orders: map[string][]string{
+		"buylimit":  {orderState(false, "2", "0")},
+		"selllimit": {orderState(true, "2", "2"), orderState(false, "2", "0")},
+	}}
+	if _, err := runBracket(t, e); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(e.requests) != "[buylimit 2@0.01 selllimit 2@0.012 cancel selllimit]" {
+		t.Errorf("unexpected requests %v", e.requests)
+	}
+}
+
+func TestBracketEntryCanceled(t *testing.T) {
+	e := &bracketExchange{orders: map[string][]string{
+		"buylimit": {orderState(false, "2", "2")},
+	}}
+	if _, err := runBracket(t, e); !errors.Is(err, ErrOrderCanceled) {
+		t.Errorf("expected ErrOrderCanceled, got %v", err)
+	}
+	if fmt.Sprint(e.requests) != "[buylimit 2@0.01]" {
+		t.Errorf("unexpected requests %v", e.requests)
+	}
+}
+
+func TestBracketCancelClosedOrder(t *testing.T) {
+	// the entry fills while the bracket is canceled
+	e := &bracketExchange{cancel: `{"success":false,"message":"ORDER_NOT_OPEN","result":null}`, orders: map[string][]string{
+		"buylimit": {orderState(true, "2", "2")},
+	}}
+	bt := newTestBittrex(e.handle)
+	bt.SetBracketPollInterval(time.Hour)
+	d := decimal.RequireFromString
+	h, err := bt.PlaceBracket(context.Background(), "BTC-LTC", BUY, d("2"), d("0.01"), d("0.009"), d("0.012"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = h.Cancel(); err != nil {
+		t.Errorf("expected the closed entry skipped, got %v", err)
+	}
+	if fmt.Sprint(e.requests) != "[buylimit 2@0.01 cancel buylimit]" {
+		t.Errorf("unexpected requests %v", e.requests)
+	}
+}
@@ -0,0 +1,63 @@
+package bittrex
+
+import (
+	"context"
+	"time"
+)
+
+// CallOption customizes a single call to Bittrex API.
+// Available options are:
+//
+//	WithContext: carries the deadline and cancellation of the call.
+//	WithTimeout: overrides the client timeout for the call.
+//	WithRetry: retries the call on network or server errors.
+//	WithNoCache: bypasses the client cache (see SetCacheTTL) and the caches between the client and Bittrex.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	ctx     context.Context
+	timeout time.Duration
+	retries int
+	noCache bool
+	// signs the request with the v3 scheme instead of the v1.1 one, set by doV3
+	signV3 bool
+}
+
+// newCallOptions returns the call options set by opts
+func newCallOptions(opts []CallOption) callOptions {
+	o := callOptions{ctx: context.Background()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithContext sets the context of the call. The call is aborted when ctx is done.
+func WithContext(ctx context.Context) CallOption {
+	return func(o *callOptions) {
+		o.ctx = ctx
+	}
+}
+
+// WithTimeout sets the timeout of the call, overriding the client one.
+func WithTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithRetry retries the call up to n times, with an exponential backoff, when it
+// fails because of a network or server (5xx) error. Errors returned by the API are not retried.
+func WithRetry(n int) CallOption {
+	return func(o *callOptions) {
+		o.retries = n
+	}
+}
+
+// WithNoCache asks the client cache (see SetCacheTTL), which is refreshed, and the caches
+// between the client and Bittrex to not serve a cached response.
+func WithNoCache() CallOption {
+	return func(o *callOptions) {
+		o.noCache = true
+	}
+}
@@ -0,0 +1,149 @@
+package bittrex
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CANDLE_HISTORY_START is the opening of Bittrex: GetTicksSince fetches no candle before it
+// for a market whose creation date is unknown
+var CANDLE_HISTORY_START = time.Date(2014, 2, 13, 0, 0, 0, 0, time.UTC)
+
+// candleHistoryIntervals maps the candle intervals to their v3 name and to the period
+// covered by a page of the v3 historical candles endpoint.
+var candleHistoryIntervals = map[string]struct {
+	name   string
+	period string // day, month or year
+}{
+	"oneMin":  {"MINUTE_1", "day"},
+	"fiveMin": {"MINUTE_5", "day"},
+	"hour":    {"HOUR_1", "month"},
+	"day":     {"DAY_1", "year"},
+}
+
+// v3Candle is a candle of the v3 API
+type v3Candle struct {
+	StartsAt    time.Time       `json:"startsAt"`
+	Open        decimal.Decimal `json:"open"`
+	High        decimal.Decimal `json:"high"`
+	Low         decimal.Decimal `json:"low"`
+	Close       decimal.Decimal `json:"close"`
+	Volume      decimal.Decimal `json:"volume"`
+	QuoteVolume decimal.Decimal `json:"quoteVolume"`
+}
+
+// GetTicksSince returns the candles of market for interval since start, sorted by time
+// (oldest first). Unlike GetTicks, whose window is limited, it pages backward from now
+// through the historical candles of the v3 API, one day (oneMin and fiveMin), month
+// (hour) or year (day) per request. thirtyMin is not supported by this API.
+// Paging stops at start, or at the creation of the market (see GetMarkets) if start is
+// before it; at CANDLE_HISTORY_START for a market without creation date. Empty pages,
+// as for an illiquid market or an outage, do not stop it. ErrZeroStart is returned for
+// a zero start. If ctx is done, the request in flight is aborted and the
+// ctx error returned.
+func (b *Bittrex) GetTicksSince(ctx context.Context, market string, interval string, start time.Time) ([]Candle, error) {
+	if market == "" {
+		return nil, ErrEmptyMarket
+	}
+	if start.IsZero() {
+		return nil, ErrZeroStart
+	}
+	history, ok := candleHistoryIntervals[interval]
+	if !ok {
+		return nil, fmt.Errorf("wrong interval %s", interval)
+	}
+	base, quote, err := SplitMarket(market)
+	if err != nil {
+		return nil, err
+	}
+	m, err := b.getMarket(market)
+	if err != nil {
+		return nil, err
+	}
+	created := CANDLE_HISTORY_START
+	if !m.Created.IsZero() {
+		created = m.Created.UTC()
+	}
+	start = start.UTC()
+	if start.Before(created) {
+		start = created
+	}
+	opts := newCallOptions([]CallOption{WithContext(ctx)})
+
+	seen := make(map[time.Time]bool)
+	var candles []Candle
+	for page := candlePeriodStart(b.client.now().UTC(), history.period); !page.Before(candlePeriodStart(start, history.period)); page = candlePreviousPeriod(page, history.period) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		endpoint := fmt.Sprintf("%smarkets/%s-%s/candles/%s/historical/%s", API_V3_BASE, quote, base, history.name, candlePeriodPath(page, history.period))
+		r, err := b.do(opts, "GET", endpoint, "", false)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, fmt.Errorf("could not get market ticks: %v", err)
+		}
+		var v3Candles []v3Candle
+		if err := b.decoder.Unmarshal(r, &v3Candles); err != nil {
+			return nil, fmt.Errorf("could not unmarshal candles: %v", err)
+		}
+		for _, c := range v3Candles {
+			// pages may overlap at their boundaries
+			if c.StartsAt.Before(start) || seen[c.StartsAt] {
+				continue
+			}
+			seen[c.StartsAt] = true
+			candles = append(candles, Candle{
+				TimeStamp:  CandleTime{c.StartsAt},
+				Open:       c.Open,
+				Close:      c.Close,
+				High:       c.High,
+				Low:        c.Low,
+				Volume:     c.Volume,
+				BaseVolume: c.QuoteVolume,
+			})
+		}
+	}
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].TimeStamp.Before(candles[j].TimeStamp.Time)
+	})
+	return candles, nil
+}
+
+// candlePeriodStart returns the start of the period containing t
+func candlePeriodStart(t time.Time, period string) time.Time {
+	switch period {
+	case "year":
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// candlePreviousPeriod returns the start of the period before the one starting at t
+func candlePreviousPeriod(t time.Time, period string) time.Time {
+	switch period {
+	case "year":
+		return t.AddDate(-1, 0, 0)
+	case "month":
+		return t.AddDate(0, -1, 0)
+	}
+	return t.AddDate(0, 0, -1)
+}
+
+// candlePeriodPath returns the path of the period starting at t in the v3 historical candles endpoint
+func candlePeriodPath(t time.Time, period string) string {
+	switch period {
+	case "year":
+		return fmt.Sprintf("%d", t.Year())
+	case "month":
+		return fmt.Sprintf("%d/%d", t.Year(), t.Month())
+	}
+	return fmt.Sprintf("%d/%d/%d", t.Year(), t.Month(), t.Day())
+}
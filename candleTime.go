@@ -18,6 +18,9 @@ type CandleTime struct {
 }
 
 func (t *CandleTime) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
 	if len(b) < 2 {
 		return fmt.Errorf("could not parse time %s", string(b))
 	}
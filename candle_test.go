@@ -0,0 +1,131 @@
+package bittrex
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetTicks(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if !strings.HasPrefix(req.URL.String(), API_V2_BASE+"pub/market/GetTicks?") {
+			t.Errorf("unexpected URL %s", req.URL)
+		}
+		if req.URL.Query().Get("tickInterval") != "hour" || req.URL.Query().Get("marketName") != "BTC-LTC" {
+			t.Errorf("unexpected query %s", req.URL.RawQuery)
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":[{"O":0.0117,"H":0.0118,"L":0.0116,"C":0.01175,"V":120.5,"T":"2018-01-22T10:00:00","BV":1.41}]}`
+	})
+	candles, err := bt.GetTicks("btc-ltc", "hour")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 1 {
+		t.Fatalf("expected 1 candle, got %d", len(candles))
+	}
+	c := candles[0]
+	if !c.TimeStamp.Equal(time.Date(2018, 1, 22, 10, 0, 0, 0, time.UTC)) || c.Open.String() != "0.0117" || c.Close.String() != "0.01175" || c.Volume.String() != "120.5" {
+		t.Errorf("unexpected candle %+v", c)
+	}
+
+	if _, err := bt.GetTicks("BTC-LTC", "week"); err == nil {
+		t.Error("expected an error for an unknown interval")
+	}
+}
+
+func TestGetLatestTick(t *testing.T) {
+	result := `[{"O":0.0117,"H":0.0118,"L":0.0116,"C":0.01175,"V":120.5,"T":"2018-01-22T10:00:00","BV":1.41}]`
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":true,"message":"","result":` + result + `}`
+	})
+	candle, err := bt.GetLatestTick("BTC-LTC", "hour")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if candle.Close.String() != "0.01175" {
+		t.Errorf("unexpected candle %+v", candle)
+	}
+
+	result = `[]`
+	if _, err := bt.GetLatestTick("BTC-LTC", "hour"); err == nil {
+		t.Error("expected an error for an empty result")
+	}
+	if _, err := bt.GetLatestTick("BTC-LTC", "week"); err == nil {
+		t.Error("expected an error for an unknown interval")
+	}
+}
+
+func TestGetTicksSince(t *testing.T) {
+	var paths []string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if strings.HasSuffix(req.URL.Path, "/public/getmarkets") {
+			return http.StatusOK, `{"success":true,"message":"","result":[{"MarketName":"BTC-LTC","Created":"2014-02-13T00:00:00"}]}`
+		}
+		paths = append(paths, req.URL.Path)
+		switch req.URL.Path {
+		case "/v3/markets/LTC-BTC/candles/HOUR_1/historical/2020/2":
+			return http.StatusOK, `[{"startsAt":"2020-02-01T00:00:00Z","open":"2","high":"2","low":"2","close":"2","volume":"1","quoteVolume":"2"}]`
+		case "/v3/markets/LTC-BTC/candles/HOUR_1/historical/2020/1":
+			return http.StatusOK, `[{"startsAt":"2020-01-15T00:00:00Z","open":"0","high":"0","low":"0","close":"0","volume":"1","quoteVolume":"0"},
+				{"startsAt":"2020-01-31T23:00:00Z","open":"1","high":"1","low":"1","close":"1","volume":"1","quoteVolume":"1"},
+				{"startsAt":"2020-02-01T00:00:00Z","open":"2","high":"2","low":"2","close":"2","volume":"1","quoteVolume":"2"}]`
+		}
+		return http.StatusNotFound, ""
+	})
+	bt.SetClock(func() time.Time { return time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC) })
+
+	candles, err := bt.GetTicksSince(context.Background(), "BTC-LTC", "hour", time.Date(2020, 1, 20, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 2 {
+		t.Errorf("expected 2 pages, got %v", paths)
+	}
+	// the candle before start is dropped and the one on the page boundary is deduplicated
+	if len(candles) != 2 || candles[0].Close.String() != "1" || candles[1].Close.String() != "2" {
+		t.Errorf("unexpected candles %+v", candles)
+	}
+}
+
+func TestGetTicksSinceBounds(t *testing.T) {
+	var paths []string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if strings.HasSuffix(req.URL.Path, "/public/getmarkets") {
+			return http.StatusOK, `{"success":true,"message":"","result":[{"MarketName":"BTC-LTC","Created":"2017-06-01T00:00:00"}]}`
+		}
+		paths = append(paths, req.URL.Path)
+		if req.URL.Path == "/v3/markets/LTC-BTC/candles/DAY_1/historical/2019" {
+			return http.StatusOK, `[{"startsAt":"2019-01-01T00:00:00Z","open":"1","high":"1","low":"1","close":"1","volume":"1","quoteVolume":"1"}]`
+		}
+		return http.StatusOK, `[]`
+	})
+	bt.SetClock(func() time.Time { return time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC) })
+
+	if _, err := bt.GetTicksSince(context.Background(), "BTC-LTC", "day", time.Time{}); err != ErrZeroStart {
+		t.Errorf("expected ErrZeroStart, got %v", err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("expected no request for a zero start, got %v", paths)
+	}
+
+	// paging goes past the empty 2020 page and stops at the creation of the market in 2017
+	candles, err := bt.GetTicksSince(context.Background(), "BTC-LTC", "day", time.Unix(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 4 || paths[3] != "/v3/markets/LTC-BTC/candles/DAY_1/historical/2017" || len(candles) != 1 {
+		t.Errorf("expected pages 2020 to 2017 and 1 candle, got %v and %+v", paths, candles)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	paths = nil
+	if _, err := bt.GetTicksSince(ctx, "BTC-LTC", "day", time.Unix(1, 0)); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("expected no request with a done ctx, got %v", paths)
+	}
+}
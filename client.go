@@ -1,30 +1,99 @@
 package bittrex
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha512"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/http/httputil"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// DEFAULT_USER_AGENT is the default User-Agent header of the requests to Bittrex API
+const DEFAULT_USER_AGENT = "go-bittrex/1.1"
+
+// DEFAULT_MAX_RESPONSE_BYTES is the default maximum size of a response body
+const DEFAULT_MAX_RESPONSE_BYTES = 64 << 20
+
+// RequestHook is called after each HTTP request to Bittrex API with the request URL,
+// whose API key is redacted, the request and response bodies, and the error if any.
+type RequestHook func(method, url string, reqBody, respBody []byte, err error)
+
+// redactURL returns u with the API key replaced by REDACTED
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	if q.Get("apikey") == "" {
+		return u.String()
+	}
+	q.Set("apikey", "REDACTED")
+	redacted := *u
+	redacted.RawQuery = q.Encode()
+	return redacted.String()
+}
+
+// RequestMetrics describes a request to Bittrex API, reported to the metrics observer
+type RequestMetrics struct {
+	Method        string
+	Resource      string // resource as requested, without authentication parameters
+	StatusCode    int    // 0 if no response was received
+	Duration      time.Duration
+	BytesSent     int64 // size of the URL and body sent
+	BytesReceived int64 // size of the response body
+	Err           error
+}
+
 type client struct {
+	// bandwidth counters, first to be 64-bit aligned for atomic operations
+	bytesSent     int64
+	bytesReceived int64
+	// last nonce of a signed v1.1 request, see nextNonce
+	lastNonce int64
+
 	apiKey      string
 	apiSecret   string
 	httpClient  *http.Client
 	httpTimeout time.Duration
 	debug       bool
+	logger      *log.Logger
+	observer    func(RequestMetrics)
+	// maximum size of a response body, DEFAULT_MAX_RESPONSE_BYTES if <= 0
+	maxResponseBytes int64
+	// source of the current time, time.Now if nil
+	clock func() time.Time
+	// paces the requests, nil if unlimited
+	limiter *rateLimiter
+	// if true, mutating requests are logged instead of sent
+	dryRun bool
+	// called after each request with the exchanged data, if not nil
+	hook RequestHook
+	// base URL of the v1.1 API, API_BASE if empty
+	baseURL string
+	// User-Agent header of the requests, DEFAULT_USER_AGENT if empty
+	userAgent string
+	// timeouts of the reading and mutating requests, httpTimeout if zero
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+
+	// liveness of the connection to Bittrex, updated by do
+	mu          sync.Mutex
+	lastSuccess time.Time
+	lastErr     error
 }
 
 // NewClient return a new Bittrex HTTP client
 func NewClient(apiKey, apiSecret string) (c *client) {
-	return &client{apiKey, apiSecret, &http.Client{}, 30 * time.Second, false}
+	return &client{apiKey: apiKey, apiSecret: apiSecret, httpClient: &http.Client{}, httpTimeout: 30 * time.Second}
 }
 
 // NewClientWithCustomHttpConfig returns a new Bittrex HTTP client using the predefined http client
@@ -33,37 +102,88 @@ func NewClientWithCustomHttpConfig(apiKey, apiSecret string, httpClient *http.Cl
 	if timeout <= 0 {
 		timeout = 30 * time.Second
 	}
-	return &client{apiKey, apiSecret, httpClient, timeout, false}
+	return &client{apiKey: apiKey, apiSecret: apiSecret, httpClient: httpClient, httpTimeout: timeout}
 }
 
 // NewClient returns a new Bittrex HTTP client with custom timeout
 func NewClientWithCustomTimeout(apiKey, apiSecret string, timeout time.Duration) (c *client) {
-	return &client{apiKey, apiSecret, &http.Client{}, timeout, false}
+	return &client{apiKey: apiKey, apiSecret: apiSecret, httpClient: &http.Client{}, httpTimeout: timeout}
+}
+
+// BytesSent returns the number of bytes sent to Bittrex API (URLs and bodies)
+func (c *client) BytesSent() int64 {
+	return atomic.LoadInt64(&c.bytesSent)
+}
+
+// BytesReceived returns the number of bytes received from Bittrex API (response bodies)
+func (c *client) BytesReceived() int64 {
+	return atomic.LoadInt64(&c.bytesReceived)
+}
+
+// LastSuccessfulCall returns the time of the last request which succeeded
+func (c *client) LastSuccessfulCall() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastSuccess
+}
+
+// LastError returns the error of the last request, nil if it succeeded
+func (c *client) LastError() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastErr
+}
+
+// recordResult updates the liveness status with the result of a request
+func (c *client) recordResult(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastErr = err
+	if err == nil {
+		c.lastSuccess = c.now()
+	}
 }
 
-func (c client) dumpRequest(r *http.Request) {
+// now returns the current time according to the client clock
+func (c *client) now() time.Time {
+	if c.clock != nil {
+		return c.clock()
+	}
+	return time.Now()
+}
+
+// logf logs a message with the client logger, or the standard one if not set
+func (c *client) logf(format string, v ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, v...)
+		return
+	}
+	log.Printf(format, v...)
+}
+
+func (c *client) dumpRequest(r *http.Request) {
 	if r == nil {
-		log.Print("dumpReq ok: <nil>")
+		c.logf("dumpReq ok: <nil>")
 		return
 	}
 	dump, err := httputil.DumpRequest(r, true)
 	if err != nil {
-		log.Print("dumpReq err:", err)
+		c.logf("dumpReq err: %v", err)
 	} else {
-		log.Print("dumpReq ok:", string(dump))
+		c.logf("dumpReq ok: %s", dump)
 	}
 }
 
-func (c client) dumpResponse(r *http.Response) {
+func (c *client) dumpResponse(r *http.Response) {
 	if r == nil {
-		log.Print("dumpResponse ok: <nil>")
+		c.logf("dumpResponse ok: <nil>")
 		return
 	}
 	dump, err := httputil.DumpResponse(r, true)
 	if err != nil {
-		log.Print("dumpResponse err:", err)
+		c.logf("dumpResponse err: %v", err)
 	} else {
-		log.Print("dumpResponse ok:", string(dump))
+		c.logf("dumpResponse ok: %s", dump)
 	}
 }
 
@@ -94,56 +214,269 @@ func (c *client) doTimeoutRequest(timer *time.Timer, req *http.Request) (*http.R
 	}
 }
 
+// doer performs the requests to Bittrex API, as client does. Bittrex depends on it rather
+// than on the client, so tests can replace it to serve canned responses without HTTP.
+// The v3 requests, signed differently, are still performed by the client.
+type doer interface {
+	do(method string, resource string, payload string, authNeeded bool) (response []byte, err error)
+}
+
 // do prepare and process HTTP request to Bittrex API
 func (c *client) do(method string, resource string, payload string, authNeeded bool) (response []byte, err error) {
-	connectTimer := time.NewTimer(c.httpTimeout)
+	return c.doWithOptions(newCallOptions(nil), method, resource, payload, authNeeded)
+}
+
+// doWithOptions prepare and process HTTP request to Bittrex API, customized by the call options
+func (c *client) doWithOptions(opts callOptions, method string, resource string, payload string, authNeeded bool) (response []byte, err error) {
+	// fail before waiting for the rate limiter, the request could not be signed anyway
+	if authNeeded && (c.apiKey == "" || c.apiSecret == "") {
+		return nil, ErrAuthNotConfigured
+	}
+	if c.dryRun && isMutating(method, resource) {
+		return c.dryRunResponse(opts, method, resource), nil
+	}
+	defer func() { c.recordResult(err) }()
+	for attempt := 0; ; attempt++ {
+		if err = c.limiter.wait(opts.ctx); err != nil {
+			return
+		}
+		var retry bool
+		response, retry, err = c.doOnce(opts, method, resource, payload, authNeeded)
+		if err == nil || !retry || attempt >= opts.retries {
+			return
+		}
+		select {
+		case <-opts.ctx.Done():
+			return response, opts.ctx.Err()
+		case <-time.After(retryDelay(attempt)):
+		}
+	}
+}
+
+// mutatingResources are the resources placing or canceling orders and moving funds
+var mutatingResources = map[string]bool{
+	"market/buylimit":   true,
+	"market/selllimit":  true,
+	"market/buymarket":  true,
+	"market/sellmarket": true,
+	"market/cancel":     true,
+	"account/withdraw":  true,
+}
+
+// isMutating returns whether a request places or cancels an order or moves funds.
+// All the requests of the v1.1 API are GET, and those of the v3 API which are not
+// GET are mutating.
+func isMutating(method, resource string) bool {
+	if method != "GET" {
+		return true
+	}
+	path := resource
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	return mutatingResources[path]
+}
+
+// dryRunResponse logs a request instead of sending it, and returns a successful response
+// with a fake uuid derived from the request.
+func (c *client) dryRunResponse(opts callOptions, method, resource string) []byte {
+	sum := sha512.Sum512([]byte(method + " " + resource))
+	uuid := "dryrun-" + hex.EncodeToString(sum[:8])
+	c.logf("dry run: %s %s -> %s", method, resource, uuid)
+	if opts.signV3 {
+		return []byte(`{"id":"` + uuid + `"}`)
+	}
+	return []byte(`{"success":true,"message":"","result":{"uuid":"` + uuid + `"}}`)
+}
+
+// nextNonce returns the nonce of a signed v1.1 request: the current time in nanoseconds,
+// bumped if needed so that nonces are strictly increasing even for concurrent requests
+// made within the same clock tick.
+func (c *client) nextNonce() int64 {
+	for {
+		last := atomic.LoadInt64(&c.lastNonce)
+		nonce := c.now().UnixNano()
+		if nonce <= last {
+			nonce = last + 1
+		}
+		if atomic.CompareAndSwapInt64(&c.lastNonce, last, nonce) {
+			return nonce
+		}
+	}
+}
+
+// Sign returns the signature of a request of the v1.1 API, sent in its apisign header:
+// the hex encoded HMAC-SHA512, keyed with the API secret, of the full URL of the request,
+// apikey and nonce query parameters included. It helps to investigate
+// APISIGN_NOT_PROVIDED or INVALID_SIGNATURE errors.
+func Sign(secret, url string) string {
+	mac := hmac.New(sha512.New, []byte(secret))
+	mac.Write([]byte(url))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// doV3 prepare and process HTTP request to Bittrex API v3, whose requests are signed
+// with headers. resource is an absolute URL and payload a JSON body.
+func (c *client) doV3(method string, resource string, payload string) (response []byte, err error) {
+	opts := newCallOptions(nil)
+	opts.signV3 = true
+	return c.doWithOptions(opts, method, resource, payload, true)
+}
+
+// signV3 signs req, whose body is payload, with the v3 scheme: an HMAC-SHA512 of the
+// timestamp, URL, method and hash of the body, sent with them in headers.
+func (c *client) signV3(req *http.Request, payload string) {
+	timestamp := strconv.FormatInt(c.now().UnixNano()/int64(time.Millisecond), 10)
+	hash := sha512.Sum512([]byte(payload))
+	contentHash := hex.EncodeToString(hash[:])
+	mac := hmac.New(sha512.New, []byte(c.apiSecret))
+	mac.Write([]byte(timestamp + req.URL.String() + req.Method + contentHash))
+	req.Header.Set("Api-Key", c.apiKey)
+	req.Header.Set("Api-Timestamp", timestamp)
+	req.Header.Set("Api-Content-Hash", contentHash)
+	req.Header.Set("Api-Signature", hex.EncodeToString(mac.Sum(nil)))
+}
+
+// retryDelay returns the time to wait, on a real timer (see SetClock), before retrying a
+// request which failed attempt+1 times
+func retryDelay(attempt int) time.Duration {
+	return 200 * time.Millisecond << uint(attempt)
+}
+
+// timeout returns the timeout of a request: the one of the call if set, else the read or
+// write one according to the request, else the client one.
+func (c *client) timeout(opts callOptions, method, resource string) time.Duration {
+	timeout := c.readTimeout
+	if isMutating(method, resource) {
+		timeout = c.writeTimeout
+	}
+	switch {
+	case opts.timeout > 0:
+		return opts.timeout
+	case timeout > 0:
+		return timeout
+	}
+	return c.httpTimeout
+}
+
+// doOnce process a single HTTP request to Bittrex API.
+// retry is true if the request failed because of a network or server error.
+func (c *client) doOnce(opts callOptions, method string, resource string, payload string, authNeeded bool) (response []byte, retry bool, err error) {
+	timeout := c.timeout(opts, method, resource)
+	connectTimer := time.NewTimer(timeout)
+	defer connectTimer.Stop()
+	// also cancel the request itself once timed out, not only the wait for it
+	ctx, cancel := context.WithTimeout(opts.ctx, timeout)
+	defer cancel()
 
 	var rawurl string
 	if strings.HasPrefix(resource, "http") {
 		rawurl = resource
 	} else {
-		rawurl = fmt.Sprintf("%s%s/%s", API_BASE, API_VERSION, resource)
+		base := c.baseURL
+		if base == "" {
+			base = API_BASE
+		}
+		rawurl = fmt.Sprintf("%s%s/%s", base, API_VERSION, resource)
 	}
 
-	req, err := http.NewRequest(method, rawurl, strings.NewReader(payload))
+	// the v1.1 API takes its parameters in the query, only v3 requests have a JSON body
+	var body io.Reader
+	if payload != "" {
+		body = strings.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, rawurl, body)
 	if err != nil {
 		return
 	}
-	if method == "POST" || method == "PUT" {
+	req = req.WithContext(ctx)
+	if payload != "" {
 		req.Header.Add("Content-Type", "application/json;charset=utf-8")
 	}
 	req.Header.Add("Accept", "application/json")
+	userAgent := c.userAgent
+	if userAgent == "" {
+		userAgent = DEFAULT_USER_AGENT
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if opts.noCache {
+		req.Header.Add("Cache-Control", "no-cache")
+	}
 
 	// Auth
 	if authNeeded {
-		if len(c.apiKey) == 0 || len(c.apiSecret) == 0 {
-			err = errors.New("You need to set API Key and API Secret to call this method")
-			return
+		if opts.signV3 {
+			c.signV3(req, payload)
+		} else {
+			q := req.URL.Query()
+			q.Set("apikey", c.apiKey)
+			q.Set("nonce", strconv.FormatInt(c.nextNonce(), 10))
+			req.URL.RawQuery = q.Encode()
+			req.Header.Add("apisign", Sign(c.apiSecret, req.URL.String()))
 		}
-		nonce := time.Now().UnixNano()
-		q := req.URL.Query()
-		q.Set("apikey", c.apiKey)
-		q.Set("nonce", fmt.Sprintf("%d", nonce))
-		req.URL.RawQuery = q.Encode()
-		mac := hmac.New(sha512.New, []byte(c.apiSecret))
-		_, err = mac.Write([]byte(req.URL.String()))
-		sig := hex.EncodeToString(mac.Sum(nil))
-		req.Header.Add("apisign", sig)
+	}
+
+	metrics := RequestMetrics{
+		Method:    method,
+		Resource:  resource,
+		BytesSent: int64(len(req.URL.String()) + len(payload)),
+	}
+	atomic.AddInt64(&c.bytesSent, metrics.BytesSent)
+	if c.hook != nil {
+		defer func() {
+			c.hook(method, redactURL(req.URL), []byte(payload), response, err)
+		}()
+	}
+	if c.observer != nil {
+		start := c.now()
+		defer func() {
+			metrics.Duration = c.now().Sub(start)
+			metrics.BytesReceived = int64(len(response))
+			metrics.Err = err
+			c.observer(metrics)
+		}()
 	}
 
 	resp, err := c.doTimeoutRequest(connectTimer, req)
 	if err != nil {
+		retry = opts.ctx.Err() == nil
 		return
 	}
+	metrics.StatusCode = resp.StatusCode
 
 	defer resp.Body.Close()
-	response, err = ioutil.ReadAll(resp.Body)
+	maxBytes := c.maxResponseBytes
+	if maxBytes <= 0 {
+		maxBytes = DEFAULT_MAX_RESPONSE_BYTES
+	}
+	response, err = ioutil.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	atomic.AddInt64(&c.bytesReceived, int64(len(response)))
 	//fmt.Println(fmt.Sprintf("reponse %s", response), err)
 	if err != nil {
-		return response, err
+		return response, true, err
+	}
+	if int64(len(response)) > maxBytes {
+		return nil, false, ErrResponseTooLarge
 	}
-	if resp.StatusCode != 200 {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		err = &RateLimitError{StatusCode: resp.StatusCode, RetryAfter: c.retryAfter(resp.Header.Get("Retry-After"))}
+	} else if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		err = errors.New(resp.Status)
+		retry = resp.StatusCode >= 500
+	}
+	return response, retry, err
+}
+
+// retryAfter parses the value of a Retry-After header, either a number of seconds or
+// an HTTP date. It returns 0 if the value is missing or invalid.
+func (c *client) retryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(c.now()); delay > 0 {
+			return delay
+		}
 	}
-	return response, err
+	return 0
 }
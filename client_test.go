@@ -0,0 +1,367 @@
+package bittrex
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// roundTripFunc is a http.RoundTripper serving requests with a function
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newTestBittrex returns a bittrex client whose requests are served by handler
+func newTestBittrex(handler func(req *http.Request) (status int, body string)) *Bittrex {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		status, body := handler(req)
+		return &http.Response{
+			Status:     http.StatusText(status),
+			StatusCode: status,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	return NewWithCustomHttpClient("key", "secret", &http.Client{Transport: transport})
+}
+
+func TestClientMaxResponseBytes(t *testing.T) {
+	body := `{"success":true,"message":"","result":[]}`
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, body
+	})
+
+	bt.SetMaxResponseBytes(int64(len(body)))
+	if _, err := bt.GetMarkets(); err != nil {
+		t.Fatalf("response at the limit: %v", err)
+	}
+
+	bt.SetMaxResponseBytes(int64(len(body) - 1))
+	if _, err := bt.GetMarkets(); err != ErrResponseTooLarge {
+		t.Errorf("oversized response: expected ErrResponseTooLarge, got %v", err)
+	}
+}
+
+func TestClientClock(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	var nonce string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		nonce = req.URL.Query().Get("nonce")
+		return http.StatusOK, `{"success":true,"message":"","result":[]}`
+	})
+	bt.SetClock(func() time.Time { return now })
+
+	if _, err := bt.GetBalances(); err != nil {
+		t.Fatal(err)
+	}
+	if expected := strconv.FormatInt(now.UnixNano(), 10); nonce != expected {
+		t.Errorf("nonce: expected %s, got %s", expected, nonce)
+	}
+	if !bt.LastSuccessfulCall().Equal(now) {
+		t.Errorf("last successful call: expected %s, got %s", now, bt.LastSuccessfulCall())
+	}
+}
+
+func TestSetHTTPClient(t *testing.T) {
+	bt := New("key", "secret")
+	called := false
+	bt.SetHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		called = true
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Request: req,
+			Body: ioutil.NopCloser(strings.NewReader(`{"success":true,"message":"","result":[]}`))}, nil
+	})})
+	if _, err := bt.GetMarkets(); err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Error("the request did not go through the custom http client")
+	}
+}
+
+func TestRequestHook(t *testing.T) {
+	body := `{"success":true,"message":"","result":[]}`
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, body
+	})
+	var loggedURL, loggedBody string
+	bt.SetRequestHook(func(method, url string, reqBody, respBody []byte, err error) {
+		loggedURL, loggedBody = url, string(respBody)
+	})
+	if _, err := bt.GetBalances(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(loggedURL, "apikey=key") || !strings.Contains(loggedURL, "apikey=REDACTED") {
+		t.Errorf("API key not redacted in %s", loggedURL)
+	}
+	if loggedBody != body {
+		t.Errorf("expected response body %s, got %s", body, loggedBody)
+	}
+}
+
+func TestClientBody(t *testing.T) {
+	var getBody, postBody, postType string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if req.Method == "GET" {
+			if req.Body != nil {
+				b, _ := ioutil.ReadAll(req.Body)
+				getBody = string(b)
+			}
+			return http.StatusOK, `{"success":true,"message":"","result":[]}`
+		}
+		b, _ := ioutil.ReadAll(req.Body)
+		postBody, postType = string(b), req.Header.Get("Content-Type")
+		return http.StatusOK, `{}`
+	})
+	if _, err := bt.GetBalances(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bt.client.doV3("POST", API_V3_BASE+"orders", `{"a":1}`); err != nil {
+		t.Fatal(err)
+	}
+	if getBody != "" {
+		t.Errorf("expected no body for a GET request, got %q", getBody)
+	}
+	if postBody != `{"a":1}` || !strings.HasPrefix(postType, "application/json") {
+		t.Errorf("expected a JSON body, got %q (%s)", postBody, postType)
+	}
+}
+
+func TestSetBaseURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1.1/public/getmarkets" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"success":true,"message":"","result":[{"MarketName":"BTC-LTC"}]}`))
+	}))
+	defer server.Close()
+
+	bt := New("", "")
+	bt.SetBaseURL(server.URL + "/api/")
+	markets, err := bt.GetMarkets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(markets) != 1 || markets[0].MarketName != "BTC-LTC" {
+		t.Errorf("unexpected markets %+v", markets)
+	}
+}
+
+func TestSetUserAgent(t *testing.T) {
+	var userAgent string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		userAgent = req.Header.Get("User-Agent")
+		return http.StatusOK, `{"success":true,"message":"","result":[]}`
+	})
+	if _, err := bt.GetMarkets(); err != nil {
+		t.Fatal(err)
+	}
+	if userAgent != DEFAULT_USER_AGENT {
+		t.Errorf("expected %s, got %s", DEFAULT_USER_AGENT, userAgent)
+	}
+	bt.SetUserAgent("mybot/2.0")
+	if _, err := bt.GetMarkets(); err != nil {
+		t.Fatal(err)
+	}
+	if userAgent != "mybot/2.0" {
+		t.Errorf("expected mybot/2.0, got %s", userAgent)
+	}
+}
+
+func TestPing(t *testing.T) {
+	var path string
+	body := `{"serverTime":1594594800000}`
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		path = req.URL.Path
+		return http.StatusOK, body
+	})
+	if err := bt.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/v3/ping" {
+		t.Errorf("expected /v3/ping, got %s", path)
+	}
+	body = `{}`
+	if err := bt.Ping(); err == nil {
+		t.Error("expected an error without server time")
+	}
+}
+
+func TestPingAuth(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":false,"message":"APIKEY_INVALID","result":null}`
+	})
+	if err := bt.PingAuth(); !IsAPIError(err) || err.Error() != "APIKEY_INVALID" {
+		t.Errorf("expected APIKEY_INVALID, got %v", err)
+	}
+
+	bt = newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":true,"message":"","result":[{"Currency":"NEW","Balance":{"odd":true}}]}`
+	})
+	if err := bt.PingAuth(); err != nil {
+		t.Errorf("expected accepted credentials despite an undecodable balance, got %v", err)
+	}
+}
+
+func TestDo(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if req.URL.Query().Get("market") == "BTC-XXX" {
+			return http.StatusOK, `{"success":false,"message":"INVALID_MARKET","result":null}`
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":{"Bid":1,"Ask":2,"Last":1.5,"NewField":"x"}}`
+	})
+	result, err := bt.Do("GET", "public/getticker?market=BTC-LTC", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(result) != `{"Bid":1,"Ask":2,"Last":1.5,"NewField":"x"}` {
+		t.Errorf("unexpected result %s", result)
+	}
+	if _, err := bt.Do("GET", "public/getticker?market=BTC-XXX", false); !IsAPIError(err) {
+		t.Errorf("expected an API error, got %v", err)
+	}
+
+	bt.SetReadOnly(true)
+	if _, err := bt.Do("GET", "market/cancel?uuid=abc", true); err != ErrReadOnly {
+		t.Errorf("expected ErrReadOnly, got %v", err)
+	}
+}
+
+func TestNewFromEnv(t *testing.T) {
+	t.Setenv("BITTREX_API_KEY", "key")
+	t.Setenv("BITTREX_API_SECRET", "")
+	if _, err := NewFromEnv(); err == nil {
+		t.Error("expected an error without secret")
+	}
+
+	t.Setenv("BITTREX_API_SECRET", "secret")
+	t.Setenv("BITTREX_TIMEOUT", "soon")
+	if _, err := NewFromEnv(); err == nil {
+		t.Error("expected an error for an invalid timeout")
+	}
+
+	t.Setenv("BITTREX_TIMEOUT", "5s")
+	t.Setenv("BITTREX_BASE_URL", "https://mirror.example/api/")
+	bt, err := NewFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bt.client.apiKey != "key" || bt.client.apiSecret != "secret" {
+		t.Errorf("unexpected credentials %q, %q", bt.client.apiKey, bt.client.apiSecret)
+	}
+	if bt.client.httpTimeout != 5*time.Second || bt.client.baseURL != "https://mirror.example/api/" {
+		t.Errorf("unexpected timeout %s or base URL %s", bt.client.httpTimeout, bt.client.baseURL)
+	}
+}
+
+func TestReadWriteTimeout(t *testing.T) {
+	var remaining time.Duration
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		deadline, ok := req.Context().Deadline()
+		if !ok {
+			t.Errorf("no deadline for %s", req.URL)
+		}
+		remaining = time.Until(deadline)
+		return http.StatusOK, `{"success":true,"message":"","result":{"uuid":"abc"}}`
+	})
+	bt.SetReadTimeout(2 * time.Second)
+	bt.SetWriteTimeout(time.Minute)
+
+	if _, err := bt.GetTicker("BTC-LTC"); err != nil {
+		t.Fatal(err)
+	}
+	if remaining <= 0 || remaining > 2*time.Second {
+		t.Errorf("read: expected a deadline within 2s, got %s", remaining)
+	}
+	if _, err := bt.BuyLimit("BTC-LTC", decimal.New(1, 0), decimal.New(1, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if remaining <= 30*time.Second || remaining > time.Minute {
+		t.Errorf("write: expected a deadline within 1m, got %s", remaining)
+	}
+	if _, err := bt.GetTicker("BTC-LTC", WithTimeout(5*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if remaining <= 2*time.Second || remaining > 5*time.Second {
+		t.Errorf("WithTimeout: expected a deadline within 5s, got %s", remaining)
+	}
+}
+
+func TestSign(t *testing.T) {
+	// reference value computed with: echo -n "$url" | openssl dgst -sha512 -hmac secret
+	url := "https://bittrex.com/api/v1.1/account/getbalances?apikey=key&nonce=1"
+	if sign := Sign("secret", url); sign != "097aa5839abf3e689422f43994a1a2b5ca0c117f75c66e8b6901d15a856719977e25bdbf677d9f330630807b4899d2cbcfba2aac1da962683f20d57c3eec9bd2" {
+		t.Errorf("unexpected signature %s", sign)
+	}
+
+	var apisign, signedURL string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		apisign, signedURL = req.Header.Get("apisign"), req.URL.String()
+		return http.StatusOK, `{"success":true,"message":"","result":[]}`
+	})
+	if _, err := bt.GetBalances(); err != nil {
+		t.Fatal(err)
+	}
+	if apisign != Sign("secret", signedURL) {
+		t.Errorf("apisign %s is not the signature of %s", apisign, signedURL)
+	}
+}
+
+func TestNonceConcurrent(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	var (
+		mu     sync.Mutex
+		nonces = make(map[int64]bool)
+	)
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		nonce, err := strconv.ParseInt(req.URL.Query().Get("nonce"), 10, 64)
+		if err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		if nonces[nonce] {
+			t.Errorf("nonce %d reused", nonce)
+		}
+		nonces[nonce] = true
+		mu.Unlock()
+		return http.StatusOK, `{"success":true,"message":"","result":[]}`
+	})
+	// a frozen clock makes every request happen within the same tick
+	bt.SetClock(func() time.Time { return now })
+
+	const n = 100
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := bt.GetBalances(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	for i := int64(0); i < n; i++ {
+		if !nonces[now.UnixNano()+i] {
+			t.Fatalf("expected nonces %d to %d, got %v", now.UnixNano(), now.UnixNano()+n-1, nonces)
+		}
+	}
+
+	nonces = make(map[int64]bool)
+	if _, err := bt.GetBalances(); err != nil {
+		t.Fatal(err)
+	}
+	if !nonces[now.UnixNano()+n] {
+		t.Errorf("expected the next nonce to be %d, got %v", now.UnixNano()+n, nonces)
+	}
+}
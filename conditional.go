@@ -0,0 +1,122 @@
+package bittrex
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ConditionalOperand is the comparison triggering a conditional order
+type ConditionalOperand string
+
+const (
+	CONDITION_GTE ConditionalOperand = "GTE" // triggers when the price rises to the trigger price (take profit)
+	CONDITION_LTE ConditionalOperand = "LTE" // triggers when the price falls to the trigger price (stop loss)
+)
+
+// ConditionalOrder is an order placed by Bittrex once the price of its market reaches
+// a trigger price.
+type ConditionalOrder struct {
+	Id                     string             `json:"id"`
+	MarketSymbol           string             `json:"marketSymbol"` // v3 market symbol, ex: LTC-BTC
+	Operand                ConditionalOperand `json:"operand"`
+	TriggerPrice           decimal.Decimal    `json:"triggerPrice"`
+	Status                 string             `json:"status"` // OPEN, COMPLETED or CANCELLED
+	OrderCreationErrorCode string             `json:"orderCreationErrorCode"`
+	CreatedOrderId         string             `json:"createdOrderId"`
+	CreatedAt              time.Time          `json:"createdAt"`
+}
+
+// v3Order is the order to create of a conditional order
+type v3Order struct {
+	MarketSymbol string `json:"marketSymbol"`
+	Direction    string `json:"direction"`
+	Type         string `json:"type"`
+	Quantity     string `json:"quantity"`
+	Limit        string `json:"limit,omitempty"`
+	TimeInForce  string `json:"timeInForce"`
+}
+
+// v3ConditionalOrderRequest is the body of a new conditional order
+type v3ConditionalOrderRequest struct {
+	MarketSymbol  string             `json:"marketSymbol"`
+	Operand       ConditionalOperand `json:"operand"`
+	TriggerPrice  string             `json:"triggerPrice"`
+	OrderToCreate v3Order            `json:"orderToCreate"`
+}
+
+// v3Error is an error returned by the v3 API
+type v3Error struct {
+	Code string `json:"code"`
+}
+
+// PlaceConditionalOrder places, through the v3 API, an order on side of quantity on market,
+// which Bittrex creates once the price of market compared to triggerPrice satisfies
+// operand. The order is a good til cancelled limit order at limit, or a market order if
+// limit is zero. Ex: a stop loss selling 1 LTC at market once BTC-LTC falls to 0.01:
+//
+//	b.PlaceConditionalOrder("BTC-LTC", CONDITION_LTE, decimal.RequireFromString("0.01"), SELL, decimal.New(1, 0), decimal.Zero)
+func (b *Bittrex) PlaceConditionalOrder(market string, operand ConditionalOperand, triggerPrice decimal.Decimal, side OrderSide, quantity, limit decimal.Decimal) (order ConditionalOrder, err error) {
+	if b.readOnly {
+		return order, ErrReadOnly
+	}
+	if market == "" {
+		return order, ErrEmptyMarket
+	}
+	if operand != CONDITION_GTE && operand != CONDITION_LTE {
+		return order, fmt.Errorf("unknown operand %q", operand)
+	}
+	if side != BUY && side != SELL {
+		return order, fmt.Errorf("unknown order side %q", side)
+	}
+	base, quote, err := SplitMarket(market)
+	if err != nil {
+		return
+	}
+	symbol := FormatMarket(quote, base)
+
+	q, err := b.formatOrderValue("quantity", quantity)
+	if err != nil {
+		return
+	}
+	trigger, err := b.formatOrderValue("triggerPrice", triggerPrice)
+	if err != nil {
+		return
+	}
+	toCreate := v3Order{MarketSymbol: symbol, Direction: strings.ToUpper(string(side)), Type: "MARKET", Quantity: q, TimeInForce: "IMMEDIATE_OR_CANCEL"}
+	if !limit.IsZero() {
+		if toCreate.Limit, err = b.formatOrderValue("limit", limit); err != nil {
+			return
+		}
+		toCreate.Type, toCreate.TimeInForce = "LIMIT", "GOOD_TIL_CANCELLED"
+	}
+	payload, err := json.Marshal(v3ConditionalOrderRequest{
+		MarketSymbol:  symbol,
+		Operand:       operand,
+		TriggerPrice:  trigger,
+		OrderToCreate: toCreate,
+	})
+	if err != nil {
+		return
+	}
+
+	r, err := b.client.doV3("POST", API_V3_BASE+"conditional-orders", string(payload))
+	if err != nil {
+		return order, decodeV3Error(r, err)
+	}
+	err = b.decoder.Unmarshal(r, &order)
+	return
+}
+
+// decodeV3Error returns the error of a failed v3 request as an *APIError if its
+// response holds an error code, err otherwise.
+func decodeV3Error(response []byte, err error) error {
+	var e v3Error
+	if json.Unmarshal(response, &e) == nil && e.Code != "" {
+		return &APIError{Message: e.Code}
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+package bittrex
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestPlaceConditionalOrder(t *testing.T) {
+	var body map[string]interface{}
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		payload, _ := ioutil.ReadAll(req.Body)
+		hash := sha512.Sum512(payload)
+		if req.Header.Get("Api-Content-Hash") != hex.EncodeToString(hash[:]) {
+			t.Error("wrong content hash")
+		}
+		mac := hmac.New(sha512.New, []byte("secret"))
+		mac.Write([]byte(req.Header.Get("Api-Timestamp") + req.URL.String() + req.Method + req.Header.Get("Api-Content-Hash")))
+		if req.Header.Get("Api-Key") != "key" || req.Header.Get("Api-Signature") != hex.EncodeToString(mac.Sum(nil)) {
+			t.Error("wrong signature")
+		}
+		json.Unmarshal(payload, &body)
+		return http.StatusCreated, `{"id":"abc","marketSymbol":"LTC-BTC","operand":"LTE","triggerPrice":"0.01","status":"OPEN"}`
+	})
+	order, err := bt.PlaceConditionalOrder("BTC-LTC", CONDITION_LTE, decimal.RequireFromString("0.01"), SELL, decimal.New(1, 0), decimal.Zero)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Id != "abc" || order.Status != "OPEN" {
+		t.Errorf("unexpected order %+v", order)
+	}
+	toCreate, _ := body["orderToCreate"].(map[string]interface{})
+	if body["marketSymbol"] != "LTC-BTC" || body["triggerPrice"] != "0.01" || toCreate["direction"] != "SELL" || toCreate["type"] != "MARKET" {
+		t.Errorf("unexpected request body %v", body)
+	}
+}
@@ -0,0 +1,26 @@
+package bittrex
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetCurrencies(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if req.URL.Path != "/api/v1.1/public/getcurrencies" {
+			t.Errorf("unexpected path %s", req.URL.Path)
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":[{"Currency":"BTC","CurrencyLong":"Bitcoin","MinConfirmation":2,"TxFee":0.00020000,"IsActive":true,"CoinType":"BITCOIN","BaseAddress":null,"Notice":null}]}`
+	})
+	currencies, err := bt.GetCurrencies()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(currencies) != 1 {
+		t.Fatalf("expected 1 currency, got %d", len(currencies))
+	}
+	c := currencies[0]
+	if c.Currency != "BTC" || c.MinConfirmation != 2 || c.TxFee.String() != "0.0002" || !c.IsActive || c.CoinType != "BITCOIN" {
+		t.Errorf("unexpected currency %+v", c)
+	}
+}
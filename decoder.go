@@ -0,0 +1,75 @@
+package bittrex
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// Decoder unmarshals the JSON responses of Bittrex API.
+// It allows to plug an implementation faster than encoding/json, such as
+// jsoniter.ConfigCompatibleWithStandardLibrary.
+type Decoder interface {
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// jsonDecoder is the default Decoder, backed by encoding/json
+type jsonDecoder struct{}
+
+func (jsonDecoder) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// SetDecoder sets the decoder used to unmarshal the responses of Bittrex API.
+// Default is encoding/json.
+func (b *Bittrex) SetDecoder(decoder Decoder) {
+	b.decoder = decoder
+}
+
+// SetTolerantDecode sets whether results of an unexpected shape are decoded in the
+// shape expected when possible: a single object where an array is expected is decoded
+// as a one element array, and a one element array where an object is expected is
+// decoded as its element. Default is false: such results are an error.
+func (b *Bittrex) SetTolerantDecode(enable bool) {
+	b.tolerantDecode = enable
+}
+
+// isNullResult returns whether the result of a response is null or missing
+func isNullResult(result json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(result)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
+
+// decodeResult unmarshals the result of a response into v. A null or missing result
+// leaves v unchanged, which is the zero value for the methods of the API.
+func (b *Bittrex) decodeResult(result json.RawMessage, v interface{}) error {
+	if isNullResult(result) {
+		return nil
+	}
+	err := b.decoder.Unmarshal(result, v)
+	if err == nil || !b.tolerantDecode {
+		return err
+	}
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return err
+	}
+	trimmed := bytes.TrimSpace(result)
+	if len(trimmed) == 0 {
+		return err
+	}
+	switch expectsArray := value.Elem().Kind() == reflect.Slice; {
+	case expectsArray && trimmed[0] == '{':
+		wrapped := make([]byte, 0, len(trimmed)+2)
+		wrapped = append(append(append(wrapped, '['), trimmed...), ']')
+		if b.decoder.Unmarshal(wrapped, v) == nil {
+			return nil
+		}
+	case !expectsArray && trimmed[0] == '[':
+		var elements []json.RawMessage
+		if b.decoder.Unmarshal(trimmed, &elements) == nil && len(elements) == 1 && b.decoder.Unmarshal(elements[0], v) == nil {
+			return nil
+		}
+	}
+	return err
+}
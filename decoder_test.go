@@ -0,0 +1,60 @@
+package bittrex
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// marketSummariesPayload returns a getmarketsummaries response with n markets
+func marketSummariesPayload(n int) []byte {
+	summaries := make([]string, n)
+	for i := range summaries {
+		summaries[i] = fmt.Sprintf(`{"MarketName":"BTC-C%03d","High":0.01203000,"Low":0.01126013,"Volume":3016.61928163,"Last":0.01168599,"BaseVolume":35.22340574,"TimeStamp":"2018-01-22T10:14:33.24","Bid":0.01168599,"Ask":0.01172496,"OpenBuyOrders":1532,"OpenSellOrders":4025,"PrevDay":0.01182000,"Created":"2014-02-13T00:00:00"}`, i)
+	}
+	return []byte(`{"success":true,"message":"","result":[` + strings.Join(summaries, ",") + `]}`)
+}
+
+func benchmarkDecodeMarketSummaries(b *testing.B, decoder Decoder) {
+	payload := marketSummariesPayload(300)
+	b.SetBytes(int64(len(payload)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var response jsonResponse
+		if err := decoder.Unmarshal(payload, &response); err != nil {
+			b.Fatal(err)
+		}
+		var summaries []MarketSummary
+		if err := decoder.Unmarshal(response.Result, &summaries); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeMarketSummariesEncodingJSON(b *testing.B) {
+	benchmarkDecodeMarketSummaries(b, jsonDecoder{})
+}
+
+func BenchmarkDecodeMarketSummariesJsoniter(b *testing.B) {
+	benchmarkDecodeMarketSummaries(b, jsoniter.ConfigCompatibleWithStandardLibrary)
+}
+
+func TestTolerantDecode(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":true,"message":"","result":{"MarketName":"BTC-LTC"}}`
+	})
+	if _, err := bt.GetMarketSummaries(); err == nil {
+		t.Fatal("expected an error decoding an object as an array")
+	}
+	bt.SetTolerantDecode(true)
+	summaries, err := bt.GetMarketSummaries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 1 || summaries[0].MarketName != "BTC-LTC" {
+		t.Errorf("expected the object as a one element array, got %+v", summaries)
+	}
+}
@@ -0,0 +1,151 @@
+package bittrex
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// APIError is an error returned by Bittrex API (a response with success set to false),
+// as opposed to a transport or decoding error. Message is the Bittrex message verbatim
+// (ex: APIKEY_INVALID, INSUFFICIENT_FUNDS).
+type APIError struct {
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is the sentinel error of the message of e (see apiErrorCodes),
+// so errors.Is(err, ErrInsufficientFunds) holds for an INSUFFICIENT_FUNDS error.
+func (e *APIError) Is(target error) bool {
+	sentinel, ok := apiErrorCodes[e.Message]
+	return ok && sentinel == target
+}
+
+// Sentinel errors of the common messages of Bittrex API, matched by errors.Is on an *APIError
+var (
+	ErrInsufficientFunds   = errors.New("insufficient funds")
+	ErrMinTradeRequirement = errors.New("minimum trade requirement not met")
+	ErrDustTrade           = errors.New("order value below the dust trade minimum")
+	ErrInvalidMarket       = errors.New("invalid market")
+	ErrInvalidCurrency     = errors.New("invalid currency")
+	ErrInvalidAPIKey       = errors.New("invalid API key")
+	ErrInvalidSignature    = errors.New("invalid signature")
+)
+
+// apiErrorCodes maps the messages of Bittrex API to their sentinel error
+var apiErrorCodes = map[string]error{
+	"INSUFFICIENT_FUNDS":              ErrInsufficientFunds,
+	"MIN_TRADE_REQUIREMENT_NOT_MET":   ErrMinTradeRequirement,
+	"DUST_TRADE_DISALLOWED_MIN_VALUE": ErrDustTrade,
+	"ORDER_NOT_OPEN":                  ErrAlreadyClosed,
+	"INVALID_MARKET":                  ErrInvalidMarket,
+	"MARKET_DOES_NOT_EXIST":           ErrInvalidMarket,
+	"INVALID_CURRENCY":                ErrInvalidCurrency,
+	"APIKEY_INVALID":                  ErrInvalidAPIKey,
+	"INVALID_SIGNATURE":               ErrInvalidSignature,
+}
+
+// IsAPIError returns whether err is, or wraps, an error returned by Bittrex API
+func IsAPIError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr)
+}
+
+// RateLimitError is returned when Bittrex API rejects a request because of rate limiting
+// (HTTP status 429). RetryAfter is the delay requested by its Retry-After header, 0 if absent.
+type RateLimitError struct {
+	StatusCode int
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limited by Bittrex API (status %d), retry after %s", e.StatusCode, e.RetryAfter)
+	}
+	return fmt.Sprintf("rate limited by Bittrex API (status %d)", e.StatusCode)
+}
+
+// ErrEmptyMarket is returned, without calling the API, when the market is empty
+var ErrEmptyMarket = errors.New("market must not be empty")
+
+// ErrEmptyCurrency is returned, without calling the API, when the currency is empty
+var ErrEmptyCurrency = errors.New("currency must not be empty")
+
+// ErrEmptyAddress is returned by withdrawal methods, without calling the API, when the address is empty
+var ErrEmptyAddress = errors.New("address must not be empty")
+
+// ErrInvalidQuantity is returned, without calling the API, for a quantity which is not positive
+var ErrInvalidQuantity = errors.New("quantity must be positive")
+
+// ErrInvalidPollInterval is returned by the polling methods, without calling the API, for
+// a poll interval which is not positive
+var ErrInvalidPollInterval = errors.New("poll interval must be positive")
+
+// ErrZeroStart is returned, without calling the API, when the start of a history is the zero time
+var ErrZeroStart = errors.New("start must not be zero")
+
+// ErrNullResult is returned by the methods placing an order or a withdrawal when Bittrex
+// reports a success without the uuid of the new order or withdrawal. The other methods
+// return the zero value for a null result.
+var ErrNullResult = errors.New("null result")
+
+// ErrAuthNotConfigured is returned by authenticated methods, without calling the API,
+// when the client was created without API key or secret (ex: environment variables not loaded).
+// Public methods work without credentials.
+var ErrAuthNotConfigured = errors.New("API key and secret required for authenticated endpoints")
+
+// ErrResponseTooLarge is returned when a response body exceeds the maximum response size
+var ErrResponseTooLarge = errors.New("response too large")
+
+// ErrReadOnly is returned by order and withdrawal methods of a read only client
+var ErrReadOnly = errors.New("client is read only")
+
+// ErrPrecisionTruncated is returned, in strict precision mode, for an order value
+// with more decimals than accepted by Bittrex.
+var ErrPrecisionTruncated = errors.New("value truncated to Bittrex precision")
+
+// ErrWouldSelfTrade is returned, when self trade prevention is enabled, for an order
+// which would match one of your own open orders.
+var ErrWouldSelfTrade = errors.New("order would trade against your own open order")
+
+// ErrInsufficientDepth is returned when the order book does not hold enough quantity to fill an order
+var ErrInsufficientDepth = errors.New("insufficient order book depth")
+
+// ErrAlreadyClosed is returned when canceling an order which is already filled or canceled
+var ErrAlreadyClosed = errors.New("order already closed")
+
+// ErrOrderNotOpen is the same error as ErrAlreadyClosed, matched by the ORDER_NOT_OPEN message
+var ErrOrderNotOpen = ErrAlreadyClosed
+
+// alreadyClosedMessages are the messages Bittrex answers when canceling a closed order
+var alreadyClosedMessages = map[string]bool{
+	"ORDER_NOT_OPEN": true,
+	"INVALID_ORDER":  true,
+}
+
+// ErrSlippageTooHigh is returned when the projected slippage of a market order exceeds the allowed maximum
+var ErrSlippageTooHigh = errors.New("slippage too high")
+
+// ErrOrderCanceled is returned when waiting for the fill of an order which got canceled
+var ErrOrderCanceled = errors.New("order canceled before being filled")
+
+// MultiError gathers the errors of operations run as a batch, where the failure
+// of one operation does not prevent the others to complete.
+type MultiError []error
+
+func (e MultiError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the gathered errors, so errors.Is and errors.As look into them.
+func (e MultiError) Unwrap() []error {
+	return e
+}
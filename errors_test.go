@@ -0,0 +1,148 @@
+package bittrex
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestAPIError(t *testing.T) {
+	status, body := http.StatusOK, `{"success":false,"message":"APIKEY_INVALID","result":null}`
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return status, body
+	})
+	_, err := bt.GetBalances()
+	if !IsAPIError(err) {
+		t.Fatalf("expected an API error, got %v", err)
+	}
+	if apiErr := err.(*APIError); apiErr.Message != "APIKEY_INVALID" {
+		t.Errorf("expected APIKEY_INVALID, got %s", apiErr.Message)
+	}
+
+	status, body = http.StatusOK, `not json`
+	if _, err = bt.GetBalances(); err == nil || IsAPIError(err) {
+		t.Errorf("expected a decoding error, got %v", err)
+	}
+}
+
+func TestAPIErrorCodes(t *testing.T) {
+	message := "INSUFFICIENT_FUNDS"
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":false,"message":"` + message + `","result":null}`
+	})
+	_, err := bt.BuyLimit("BTC-LTC", decimal.New(1, 0), decimal.New(1, 0))
+	if !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("expected ErrInsufficientFunds, got %v", err)
+	}
+	if errors.Is(err, ErrDustTrade) {
+		t.Errorf("%v should not be ErrDustTrade", err)
+	}
+
+	message = "DUST_TRADE_DISALLOWED_MIN_VALUE"
+	_, err = bt.BuyLimit("BTC-LTC", decimal.New(1, 0), decimal.New(1, 0))
+	if !errors.Is(fmt.Errorf("buying: %w", err), ErrDustTrade) {
+		t.Errorf("expected a wrapped ErrDustTrade, got %v", err)
+	}
+
+	message = "ORDER_NOT_OPEN"
+	err = bt.CancelOrder("abc")
+	if !errors.Is(err, ErrOrderNotOpen) || !errors.Is(err, ErrAlreadyClosed) || !IsAPIError(err) {
+		t.Errorf("CancelOrder: expected ErrOrderNotOpen and ErrAlreadyClosed, got %v", err)
+	}
+	_, err = bt.GetOrder("abc")
+	if !errors.Is(err, ErrAlreadyClosed) {
+		t.Errorf("GetOrder: expected ErrAlreadyClosed for ORDER_NOT_OPEN, got %v", err)
+	}
+
+	message = "SOMETHING_NEW"
+	_, err = bt.BuyLimit("BTC-LTC", decimal.New(1, 0), decimal.New(1, 0))
+	if !IsAPIError(err) || errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("expected an unclassified API error, got %v", err)
+	}
+}
+
+func TestRateLimitError(t *testing.T) {
+	bt := NewWithCustomHttpClient("key", "secret", &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		header.Set("Retry-After", "30")
+		return &http.Response{Status: "429 Too Many Requests", StatusCode: http.StatusTooManyRequests, Header: header, Request: req,
+			Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+	})})
+	_, err := bt.GetMarkets()
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("expected a RateLimitError, got %v", err)
+	}
+	if rateLimitErr.StatusCode != http.StatusTooManyRequests || rateLimitErr.RetryAfter != 30*time.Second {
+		t.Errorf("unexpected error %+v", rateLimitErr)
+	}
+}
+
+func TestEmptyMarket(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		t.Errorf("unexpected request %s", req.URL)
+		return http.StatusOK, `{"success":true,"message":"","result":null}`
+	})
+	if _, err := bt.GetTicker(""); err != ErrEmptyMarket {
+		t.Errorf("GetTicker: expected ErrEmptyMarket, got %v", err)
+	}
+	if _, err := bt.SellLimit("", decimal.New(1, 0), decimal.New(1, 0)); err != ErrEmptyMarket {
+		t.Errorf("SellLimit: expected ErrEmptyMarket, got %v", err)
+	}
+	if _, err := bt.Withdraw("addr", "", decimal.New(1, 0)); err != ErrEmptyCurrency {
+		t.Errorf("Withdraw: expected ErrEmptyCurrency, got %v", err)
+	}
+}
+
+func TestWithdrawInvalidInput(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		t.Errorf("unexpected request %s", req.URL)
+		return http.StatusOK, `{"success":true,"message":"","result":{"uuid":"abc"}}`
+	})
+	if _, err := bt.Withdraw("", "BTC", decimal.New(1, 0)); err != ErrEmptyAddress {
+		t.Errorf("empty address: expected ErrEmptyAddress, got %v", err)
+	}
+	for _, q := range []string{"0", "-1", "0.000000001"} {
+		if _, err := bt.Withdraw("addr", "BTC", decimal.RequireFromString(q)); !errors.Is(err, ErrInvalidQuantity) {
+			t.Errorf("quantity %s: expected ErrInvalidQuantity, got %v", q, err)
+		}
+	}
+	if _, err := bt.WithdrawWithPaymentID("addr", "XRP", decimal.Zero, "1234"); !errors.Is(err, ErrInvalidQuantity) {
+		t.Errorf("WithdrawWithPaymentID: expected ErrInvalidQuantity, got %v", err)
+	}
+}
+
+func TestAuthNotConfigured(t *testing.T) {
+	var requests []string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"success":true,"message":"","result":[]}`)),
+			Request:    req,
+		}, nil
+	})
+	for _, creds := range [][2]string{{"", ""}, {"key", ""}, {"", "secret"}} {
+		bt := NewWithCustomHttpClient(creds[0], creds[1], &http.Client{Transport: transport})
+		if _, err := bt.GetBalances(); err != ErrAuthNotConfigured {
+			t.Errorf("GetBalances with %q: expected ErrAuthNotConfigured, got %v", creds, err)
+		}
+		if _, err := bt.BuyLimit("BTC-LTC", decimal.New(1, 0), decimal.New(1, 0)); err != ErrAuthNotConfigured {
+			t.Errorf("BuyLimit with %q: expected ErrAuthNotConfigured, got %v", creds, err)
+		}
+		if len(requests) != 0 {
+			t.Fatalf("unexpected requests %v", requests)
+		}
+		if _, err := bt.GetMarkets(); err != nil {
+			t.Errorf("GetMarkets with %q: %v", creds, err)
+		}
+		requests = nil
+	}
+}
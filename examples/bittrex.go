@@ -48,7 +48,7 @@ func main() {
 
 	// Get orders book
 	/*
-		orderBook, err := bittrex.GetOrderBook("BTC-DRK", "both")
+		orderBook, err := bittrex.GetOrderBook("BTC-DRK", "both", 20)
 		fmt.Println(err, orderBook)
 	*/
 
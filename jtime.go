@@ -12,7 +12,11 @@ type jTime struct {
 	time.Time
 }
 
+// UnmarshalJSON parses a Bittrex time. null is left as the zero time.
 func (jt *jTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
 	var s string
 	if err := json.Unmarshal(data, &s); err != nil {
 		return err
@@ -0,0 +1,74 @@
+package bittrex
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TransferDirection is the direction of a transfer of funds
+type TransferDirection string
+
+const (
+	TRANSFER_DEPOSIT    TransferDirection = "deposit"
+	TRANSFER_WITHDRAWAL TransferDirection = "withdrawal"
+)
+
+// LedgerEntry is a deposit or a withdrawal
+type LedgerEntry struct {
+	Time      time.Time
+	Currency  string
+	Direction TransferDirection
+	Amount    decimal.Decimal // positive for a deposit, negative for a withdrawal
+	Fee       decimal.Decimal // transaction cost of a withdrawal
+	Address   string
+	TxId      string
+}
+
+// TransferLedger returns the deposits and withdrawals of currency (or of all currencies if
+// currency is "all") which happened between from and to (inclusive), sorted by time.
+// Deposits are dated when last updated and withdrawals when opened. Canceled withdrawals
+// are ignored.
+func (b *Bittrex) TransferLedger(currency string, from, to time.Time) (ledger []LedgerEntry, err error) {
+	deposits, err := b.GetDepositHistory(currency)
+	if err != nil {
+		return
+	}
+	withdrawals, err := b.GetWithdrawalHistory(currency)
+	if err != nil {
+		return
+	}
+	inPeriod := func(t time.Time) bool {
+		return !t.Before(from) && !t.After(to)
+	}
+	for _, deposit := range deposits {
+		if inPeriod(deposit.LastUpdated.Time) {
+			ledger = append(ledger, LedgerEntry{
+				Time:      deposit.LastUpdated.Time,
+				Currency:  deposit.Currency,
+				Direction: TRANSFER_DEPOSIT,
+				Amount:    deposit.Amount,
+				Address:   deposit.CryptoAddress,
+				TxId:      deposit.TxId,
+			})
+		}
+	}
+	for _, withdrawal := range withdrawals {
+		if !withdrawal.Canceled && inPeriod(withdrawal.Opened.Time) {
+			ledger = append(ledger, LedgerEntry{
+				Time:      withdrawal.Opened.Time,
+				Currency:  withdrawal.Currency,
+				Direction: TRANSFER_WITHDRAWAL,
+				Amount:    withdrawal.Amount.Neg(),
+				Fee:       withdrawal.TxCost,
+				Address:   withdrawal.Address,
+				TxId:      withdrawal.TxId,
+			})
+		}
+	}
+	sort.SliceStable(ledger, func(i, j int) bool {
+		return ledger[i].Time.Before(ledger[j].Time)
+	})
+	return
+}
@@ -1,6 +1,11 @@
 package bittrex
 
-import "github.com/shopspring/decimal"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
 
 type Market struct {
 	MarketCurrency     string          `json:"MarketCurrency"`
@@ -13,4 +18,38 @@ type Market struct {
 	Notice             string          `json:"Notice"`
 	IsSponsored        bool            `json:"IsSponsored"`
 	LogoUrl            string          `json:"LogoUrl"`
+	Created            jTime           `json:"Created"`
+	// Precision is the number of decimals of the market rates, nil when not provided by the API
+	Precision *int `json:"Precision"`
+}
+
+// PriceTick returns the smallest rate increment of the market,
+// derived from Precision, or DEFAULT_STEP if not provided.
+func (m Market) PriceTick() decimal.Decimal {
+	if m.Precision == nil {
+		return DEFAULT_STEP
+	}
+	return decimal.New(1, -int32(*m.Precision))
+}
+
+// QuantityStep returns the smallest quantity increment of the market.
+// Bittrex does not provide a step per market, so it is DEFAULT_STEP.
+func (m Market) QuantityStep() decimal.Decimal {
+	return DEFAULT_STEP
+}
+
+// SplitMarket splits a market name (ex: BTC-LTC) in its base (BTC) and quote (LTC)
+// currencies, uppercased. An error is returned if market is not two currencies
+// separated by a dash.
+func SplitMarket(market string) (base, quote string, err error) {
+	parts := strings.Split(strings.ToUpper(market), "-")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid market %q", market)
+	}
+	return parts[0], parts[1], nil
+}
+
+// FormatMarket returns the name of the market of quote in base (ex: BTC-LTC for BTC and LTC)
+func FormatMarket(base, quote string) string {
+	return strings.ToUpper(base) + "-" + strings.ToUpper(quote)
 }
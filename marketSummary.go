@@ -1,6 +1,11 @@
 package bittrex
 
-import "github.com/shopspring/decimal"
+import (
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
 
 type MarketSummary struct {
 	MarketName     string          `json:"MarketName"`
@@ -16,3 +21,45 @@ type MarketSummary struct {
 	PrevDay        decimal.Decimal `json:"PrevDay"`
 	TimeStamp      string          `json:"TimeStamp"`
 }
+
+// PercentChange returns the 24h price change of the market, in percent:
+// (Last - PrevDay) / PrevDay * 100. It is zero if PrevDay is not provided.
+func (s MarketSummary) PercentChange() decimal.Decimal {
+	if s.PrevDay.IsZero() {
+		return decimal.Zero
+	}
+	return s.Last.Sub(s.PrevDay).Div(s.PrevDay).Mul(decimal.New(100, 0))
+}
+
+// MarketSummaries are market summaries with filtering and sorting helpers, ex: the
+// BTC markets by decreasing volume:
+//
+//	summaries, err := b.GetMarketSummaries()
+//	btc := MarketSummaries(summaries).FilterByBase("BTC")
+//	btc.SortByVolume()
+type MarketSummaries []MarketSummary
+
+// FilterByBase returns the summaries of the markets quoted in base (ex: BTC for BTC-LTC)
+func (s MarketSummaries) FilterByBase(base string) (filtered MarketSummaries) {
+	for _, summary := range s {
+		if b, _, err := SplitMarket(summary.MarketName); err == nil && b == strings.ToUpper(base) {
+			filtered = append(filtered, summary)
+		}
+	}
+	return
+}
+
+// SortByVolume sorts the summaries by decreasing 24h volume in base currency (BaseVolume).
+// Volumes are only comparable between markets of the same base, see FilterByBase.
+func (s MarketSummaries) SortByVolume() {
+	sort.SliceStable(s, func(i, j int) bool {
+		return s[i].BaseVolume.GreaterThan(s[j].BaseVolume)
+	})
+}
+
+// SortByPercentChange sorts the summaries by decreasing 24h price change (see PercentChange)
+func (s MarketSummaries) SortByPercentChange() {
+	sort.SliceStable(s, func(i, j int) bool {
+		return s[i].PercentChange().GreaterThan(s[j].PercentChange())
+	})
+}
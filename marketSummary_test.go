@@ -0,0 +1,67 @@
+package bittrex
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestGetMarketSummary(t *testing.T) {
+	result := `[{"MarketName":"BTC-LTC","Last":0.0117}]`
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if market := req.URL.Query().Get("market"); market != "BTC-LTC" {
+			t.Errorf("expected market BTC-LTC, got %s", market)
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":` + result + `}`
+	})
+	summary, err := bt.GetMarketSummary("btc-ltc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.MarketName != "BTC-LTC" || summary.Last.String() != "0.0117" {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+
+	result = `[]`
+	if _, err := bt.GetMarketSummary("btc-ltc"); err == nil {
+		t.Error("expected an error for an empty result")
+	}
+}
+
+func TestMarketSummaries(t *testing.T) {
+	summaries := MarketSummaries{
+		{MarketName: "BTC-LTC", BaseVolume: decimal.New(10, 0), Last: decimal.New(11, 0), PrevDay: decimal.New(10, 0)},
+		{MarketName: "ETH-LTC", BaseVolume: decimal.New(50, 0), Last: decimal.New(30, 0), PrevDay: decimal.New(10, 0)},
+		{MarketName: "BTC-ETH", BaseVolume: decimal.New(20, 0), Last: decimal.New(9, 0), PrevDay: decimal.New(10, 0)},
+		{MarketName: "BTC-NEW", BaseVolume: decimal.New(5, 0), Last: decimal.New(1, 0)},
+	}
+	if change := summaries[2].PercentChange().String(); change != "-10" {
+		t.Errorf("PercentChange: expected -10, got %s", change)
+	}
+	if change := summaries[3].PercentChange(); !change.IsZero() {
+		t.Errorf("PercentChange without PrevDay: expected 0, got %s", change)
+	}
+
+	btc := summaries.FilterByBase("btc")
+	btc.SortByVolume()
+	if names := marketNames(btc); names != "BTC-ETH,BTC-LTC,BTC-NEW" {
+		t.Errorf("SortByVolume: expected BTC-ETH,BTC-LTC,BTC-NEW, got %s", names)
+	}
+	btc.SortByPercentChange()
+	if names := marketNames(btc); names != "BTC-LTC,BTC-NEW,BTC-ETH" {
+		t.Errorf("SortByPercentChange: expected BTC-LTC,BTC-NEW,BTC-ETH, got %s", names)
+	}
+	if len(summaries) != 4 || summaries[0].MarketName != "BTC-LTC" {
+		t.Errorf("FilterByBase modified the summaries: %+v", summaries)
+	}
+}
+
+func marketNames(summaries MarketSummaries) string {
+	names := make([]string, len(summaries))
+	for i, s := range summaries {
+		names[i] = s.MarketName
+	}
+	return strings.Join(names, ",")
+}
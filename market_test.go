@@ -0,0 +1,18 @@
+package bittrex
+
+import "testing"
+
+func TestSplitMarket(t *testing.T) {
+	base, quote, err := SplitMarket("btc-ltc")
+	if err != nil || base != "BTC" || quote != "LTC" {
+		t.Errorf("expected BTC and LTC, got %q, %q, %v", base, quote, err)
+	}
+	for _, market := range []string{"", "BTCLTC", "BTC-", "-LTC", "BTC-LTC-ETH"} {
+		if _, _, err := SplitMarket(market); err == nil {
+			t.Errorf("expected an error for %q", market)
+		}
+	}
+	if market := FormatMarket("btc", "ltc"); market != "BTC-LTC" {
+		t.Errorf("expected BTC-LTC, got %s", market)
+	}
+}
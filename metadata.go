@@ -0,0 +1,147 @@
+package bittrex
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// marketCache keeps the markets meta data, which rarely changes, indexed by market name.
+type marketCache struct {
+	sync.Mutex
+	markets  map[string]Market
+	loadedAt time.Time
+}
+
+// getMarket returns the meta data of market from the markets list, loaded on first use,
+// again once the cache TTL has elapsed if one is set (see SetCacheTTL), and again when
+// market is missing from it, as it may have been listed since. The list is requested
+// through the cache, so concurrent loads are coalesced, without holding the lock.
+func (b *Bittrex) getMarket(market string) (Market, error) {
+	name := strings.ToUpper(market)
+	ttl := b.cache.getTTL()
+	b.marketCache.Lock()
+	m, ok := b.marketCache.markets[name]
+	stale := b.marketCache.markets == nil || ttl > 0 && b.client.now().Sub(b.marketCache.loadedAt) >= ttl
+	b.marketCache.Unlock()
+	if ok && !stale {
+		return m, nil
+	}
+	var opts []CallOption
+	if !stale && ttl > 0 {
+		// the cached response is the list market is missing from
+		opts = append(opts, WithNoCache())
+	}
+	markets, err := b.GetMarkets(opts...)
+	if err != nil {
+		return Market{}, err
+	}
+	b.marketCache.Lock()
+	b.marketCache.markets = make(map[string]Market, len(markets))
+	for _, m := range markets {
+		b.marketCache.markets[m.MarketName] = m
+	}
+	b.marketCache.loadedAt = b.client.now()
+	m, ok = b.marketCache.markets[name]
+	b.marketCache.Unlock()
+	if !ok {
+		return Market{}, fmt.Errorf("unknown market %s", market)
+	}
+	return m, nil
+}
+
+// responseCache memoizes the responses of meta data requests for a TTL. Concurrent
+// requests of a resource which is not cached are coalesced into a single request.
+type responseCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+	calls   map[string]*cacheCall
+}
+
+type cacheEntry struct {
+	response []byte
+	expires  time.Time
+}
+
+// cacheCall is a request in flight, shared by the callers of the same resource
+type cacheCall struct {
+	wg       sync.WaitGroup
+	response []byte
+	err      error
+}
+
+func (c *responseCache) getTTL() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ttl
+}
+
+// SetCacheTTL enables the cache of the meta data (GetMarkets and GetCurrencies), whose
+// responses are then served from memory for ttl. Pass 0 to disable the cache, which is
+// the default. A call with WithNoCache bypasses the cache and refreshes it.
+// The market meta data used by the order helpers (ex: ValidateOrder) is kept apart: for
+// ttl if set, otherwise until a market is missing from it or ForceRefresh is called.
+func (b *Bittrex) SetCacheTTL(ttl time.Duration) {
+	b.cache.mu.Lock()
+	defer b.cache.mu.Unlock()
+	b.cache.ttl = ttl
+	b.cache.entries = nil
+}
+
+// ForceRefresh empties the meta data cache, so the next calls request Bittrex API.
+func (b *Bittrex) ForceRefresh() {
+	b.cache.mu.Lock()
+	b.cache.entries = nil
+	b.cache.mu.Unlock()
+	b.marketCache.Lock()
+	b.marketCache.markets = nil
+	b.marketCache.Unlock()
+}
+
+// cachedDo requests the public resource, serving it from the cache if enabled.
+// Concurrent requests are coalesced, even with the cache disabled. Failed responses
+// are not cached.
+func (b *Bittrex) cachedDo(opts callOptions, resource string) ([]byte, error) {
+	c := &b.cache
+	c.mu.RLock()
+	entry, ok := c.entries[resource]
+	c.mu.RUnlock()
+	if ok && !opts.noCache && b.client.now().Before(entry.expires) {
+		return entry.response, nil
+	}
+
+	c.mu.Lock()
+	if call, ok := c.calls[resource]; ok && !opts.noCache {
+		c.mu.Unlock()
+		call.wg.Wait()
+		return call.response, call.err
+	}
+	call := &cacheCall{}
+	call.wg.Add(1)
+	if c.calls == nil {
+		c.calls = make(map[string]*cacheCall)
+	}
+	c.calls[resource] = call
+	c.mu.Unlock()
+
+	call.response, call.err = b.do(opts, "GET", resource, "", false)
+	var response jsonResponse
+	cacheable := call.err == nil && json.Unmarshal(call.response, &response) == nil && response.Success
+
+	c.mu.Lock()
+	if c.calls[resource] == call {
+		delete(c.calls, resource)
+	}
+	if cacheable && c.ttl > 0 {
+		if c.entries == nil {
+			c.entries = make(map[string]cacheEntry)
+		}
+		c.entries[resource] = cacheEntry{response: call.response, expires: b.client.now().Add(c.ttl)}
+	}
+	c.mu.Unlock()
+	call.wg.Done()
+	return call.response, call.err
+}
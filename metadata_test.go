@@ -0,0 +1,107 @@
+package bittrex
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	var requests int32
+	release := make(chan bool)
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			<-release
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":[{"MarketName":"BTC-LTC"}]}`
+	})
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	bt.SetClock(func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	})
+	bt.SetCacheTTL(time.Minute)
+
+	// concurrent misses are coalesced into a single request
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if markets, err := bt.GetMarkets(); err != nil || len(markets) != 1 {
+				t.Errorf("unexpected markets %v, %v", markets, err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+
+	expect := func(step string, expected int32) {
+		t.Helper()
+		if _, err := bt.GetMarkets(); err != nil {
+			t.Fatal(err)
+		}
+		if n := atomic.LoadInt32(&requests); n != expected {
+			t.Errorf("%s: expected %d requests, got %d", step, expected, n)
+		}
+	}
+	expect("cached", 1)
+	bt.GetMarkets(WithNoCache())
+	expect("no cache option", 2)
+	bt.ForceRefresh()
+	expect("forced refresh", 3)
+	mu.Lock()
+	now = now.Add(time.Minute)
+	mu.Unlock()
+	expect("expired", 4)
+}
+
+func TestGetMarketRefreshOnMiss(t *testing.T) {
+	var requests int32
+	release := make(chan bool)
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		switch atomic.AddInt32(&requests, 1) {
+		case 1:
+			<-release
+			return http.StatusOK, `{"success":true,"message":"","result":[{"MarketName":"BTC-LTC"}]}`
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":[{"MarketName":"BTC-LTC"},{"MarketName":"BTC-NEW"}]}`
+	})
+
+	// concurrent first loads are coalesced, the cache being disabled
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := bt.getMarket("btc-ltc"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+
+	if _, err := bt.getMarket("BTC-LTC"); err != nil || requests != 1 {
+		t.Errorf("expected BTC-LTC from the loaded list, got %v after %d requests", err, requests)
+	}
+	// a market listed since the load is found by reloading the list
+	if m, err := bt.getMarket("BTC-NEW"); err != nil || m.MarketName != "BTC-NEW" || requests != 2 {
+		t.Errorf("expected BTC-NEW after a reload, got %v after %d requests", err, requests)
+	}
+	if _, err := bt.getMarket("BTC-XXX"); err == nil || requests != 3 {
+		t.Errorf("expected an unknown market after a reload, got %v after %d requests", err, requests)
+	}
+}
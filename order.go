@@ -1,43 +1,138 @@
 package bittrex
 
-import "github.com/shopspring/decimal"
+import (
+	"encoding/json"
+	"strings"
+	"time"
 
+	"github.com/shopspring/decimal"
+)
+
+// Optional fields, which Bittrex sets to null when they do not apply (ex: the
+// limit of a market order or the price per unit of an unfilled order), are
+// pointers, nil when absent.
 type Order struct {
-	OrderUuid         string          `json:"OrderUuid"`
-	Exchange          string          `json:"Exchange"`
-	TimeStamp         jTime           `json:"TimeStamp"`
-	OrderType         string          `json:"OrderType"`
-	Limit             decimal.Decimal `json:"Limit"`
-	Quantity          decimal.Decimal `json:"Quantity"`
-	QuantityRemaining decimal.Decimal `json:"QuantityRemaining"`
-	Commission        decimal.Decimal `json:"Commission"`
-	Price             decimal.Decimal `json:"Price"`
-	PricePerUnit      decimal.Decimal `json:"PricePerUnit"`
+	OrderUuid         string           `json:"OrderUuid"`
+	Exchange          string           `json:"Exchange"`
+	TimeStamp         jTime            `json:"TimeStamp"`
+	OrderType         string           `json:"OrderType"`
+	Limit             *decimal.Decimal `json:"Limit"`
+	Quantity          decimal.Decimal  `json:"Quantity"`
+	QuantityRemaining decimal.Decimal  `json:"QuantityRemaining"`
+	Commission        decimal.Decimal  `json:"Commission"`
+	Price             decimal.Decimal  `json:"Price"`
+	PricePerUnit      *decimal.Decimal `json:"PricePerUnit"`
+	Closed            *jTime           `json:"Closed"` // set in order history, nil for open orders
+}
+
+// closeTime returns the time order was closed, or the time it was placed if unknown
+func (o Order) closeTime() time.Time {
+	if o.Closed != nil && !o.Closed.IsZero() {
+		return o.Closed.Time
+	}
+	return o.TimeStamp.Time
 }
 
 // For getorder
+// As for Order, optional fields are pointers, nil when absent.
 type Order2 struct {
 	AccountId                  string
 	OrderUuid                  string `json:"OrderUuid"`
 	Exchange                   string `json:"Exchange"`
 	Type                       string
-	Quantity                   decimal.Decimal `json:"Quantity"`
-	QuantityRemaining          decimal.Decimal `json:"QuantityRemaining"`
-	Limit                      decimal.Decimal `json:"Limit"`
+	Quantity                   decimal.Decimal  `json:"Quantity"`
+	QuantityRemaining          decimal.Decimal  `json:"QuantityRemaining"`
+	Limit                      *decimal.Decimal `json:"Limit"`
 	Reserved                   decimal.Decimal
 	ReserveRemaining           decimal.Decimal
 	CommissionReserved         decimal.Decimal
 	CommissionReserveRemaining decimal.Decimal
 	CommissionPaid             decimal.Decimal
-	Price                      decimal.Decimal `json:"Price"`
-	PricePerUnit               decimal.Decimal `json:"PricePerUnit"`
-	Opened                     string
-	Closed                     string
+	Price                      decimal.Decimal  `json:"Price"`
+	PricePerUnit               *decimal.Decimal `json:"PricePerUnit"`
+	Opened                     jTime
+	Closed                     *jTime
 	IsOpen                     bool
 	Sentinel                   string
 	CancelInitiated            bool
 	ImmediateOrCancel          bool
 	IsConditional              bool
 	Condition                  string
-	ConditionTarget            decimal.Decimal
+	ConditionTarget            *decimal.Decimal
+}
+
+// IsFilled returns whether the order is closed and completely filled
+func (o Order2) IsFilled() bool {
+	return !o.IsOpen && !o.QuantityRemaining.IsPositive()
+}
+
+// IsCanceled returns whether the order is closed without being completely filled,
+// whether it was partially filled or not.
+func (o Order2) IsCanceled() bool {
+	return !o.IsOpen && o.QuantityRemaining.IsPositive()
+}
+
+// BreakEvenPrice returns the price at which selling what was bought at buyPrice
+// nets zero once the commission has been paid on both the buy and the sell.
+func BreakEvenPrice(buyPrice, commissionRate decimal.Decimal) decimal.Decimal {
+	one := decimal.New(1, 0)
+	return buyPrice.Mul(one.Add(commissionRate)).Div(one.Sub(commissionRate))
+}
+
+// BreakEvenPrice returns the break even sell price of the order using its
+// average fill price (or its limit if it has not been filled yet).
+// commissionRate is usually the one configured on the client (see Bittrex.CommissionRate).
+func (o Order) BreakEvenPrice(commissionRate decimal.Decimal) decimal.Decimal {
+	var price decimal.Decimal
+	if o.PricePerUnit != nil {
+		price = *o.PricePerUnit
+	} else if o.Limit != nil {
+		price = *o.Limit
+	}
+	return BreakEvenPrice(price, commissionRate)
+}
+
+// FilledQuantity returns the quantity of the order which has been filled
+func (o Order) FilledQuantity() decimal.Decimal {
+	return o.Quantity.Sub(o.QuantityRemaining)
+}
+
+// IsFilled returns whether the order has been completely filled.
+// An order of zero quantity is not considered filled.
+func (o Order) IsFilled() bool {
+	return o.Quantity.IsPositive() && !o.QuantityRemaining.IsPositive()
+}
+
+// NetCost returns the amount of base currency the order moved, commission included:
+// spent (Price plus Commission) for a buy, received (Price minus Commission) for a sell.
+// It is zero for an order not filled at all.
+func (o Order) NetCost() decimal.Decimal {
+	if strings.HasSuffix(o.OrderType, "SELL") {
+		return o.Price.Sub(o.Commission)
+	}
+	return o.Price.Add(o.Commission)
+}
+
+// AveragePrice returns the average price per unit of the filled quantity, commission
+// excluded: PricePerUnit when Bittrex provides it, Price divided by the filled quantity
+// otherwise. It is zero for an order not filled at all.
+func (o Order) AveragePrice() decimal.Decimal {
+	if o.PricePerUnit != nil {
+		return *o.PricePerUnit
+	}
+	filled := o.FilledQuantity()
+	if !filled.IsPositive() {
+		return decimal.Zero
+	}
+	return o.Price.Div(filled)
+}
+
+// OrderResult is the result of an order placement
+type OrderResult struct {
+	Uuid     string          `json:"uuid"`
+	Market   string          `json:"-"`
+	Side     OrderSide       `json:"-"`
+	Quantity decimal.Decimal `json:"-"` // quantity sent, after rounding and truncation
+	Rate     decimal.Decimal `json:"-"` // rate sent, after truncation
+	Raw      json.RawMessage `json:"-"` // result as returned by Bittrex
 }
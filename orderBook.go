@@ -1,6 +1,11 @@
 package bittrex
 
-import "github.com/shopspring/decimal"
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
 
 type OrderBook struct {
 	Buy  []Orderb `json:"buy"`
@@ -11,3 +16,178 @@ type Orderb struct {
 	Quantity decimal.Decimal `json:"Quantity"`
 	Rate     decimal.Decimal `json:"Rate"`
 }
+
+// BestBid returns the buy entry with the highest rate, ok is false if there is none
+func (ob OrderBook) BestBid() (entry Orderb, ok bool) {
+	for _, e := range ob.Buy {
+		if !ok || e.Rate.GreaterThan(entry.Rate) {
+			entry, ok = e, true
+		}
+	}
+	return
+}
+
+// BestAsk returns the sell entry with the lowest rate, ok is false if there is none
+func (ob OrderBook) BestAsk() (entry Orderb, ok bool) {
+	for _, e := range ob.Sell {
+		if !ok || e.Rate.LessThan(entry.Rate) {
+			entry, ok = e, true
+		}
+	}
+	return
+}
+
+// Spread returns the difference between the best ask and bid rates, zero if a side is empty
+func (ob OrderBook) Spread() decimal.Decimal {
+	bid, okBid := ob.BestBid()
+	ask, okAsk := ob.BestAsk()
+	if !okBid || !okAsk {
+		return decimal.Decimal{}
+	}
+	return ask.Rate.Sub(bid.Rate)
+}
+
+// MidPrice returns the middle of the best bid and ask rates, zero if a side is empty
+func (ob OrderBook) MidPrice() decimal.Decimal {
+	bid, okBid := ob.BestBid()
+	ask, okAsk := ob.BestAsk()
+	if !okBid || !okAsk {
+		return decimal.Decimal{}
+	}
+	return bid.Rate.Add(ask.Rate).Div(decimal.New(2, 0))
+}
+
+// ReconstructBook approximates the order book after trades, by removing the quantity
+// of each trade from the levels of snapshot it could have matched: a BUY trade
+// consumes the sell side from the best rate up to the trade price, a SELL trade
+// consumes the buy side down to the trade price.
+// It is a rough approximation: orders placed or canceled after the snapshot are unknown.
+// trades must have happened after the snapshot, they are applied in chronological order.
+// Sides of snapshot must be sorted best rate first, as returned by GetOrderBook.
+func ReconstructBook(snapshot OrderBook, trades []Trade) OrderBook {
+	book := OrderBook{
+		Buy:  append([]Orderb(nil), snapshot.Buy...),
+		Sell: append([]Orderb(nil), snapshot.Sell...),
+	}
+	sorted := append([]Trade(nil), trades...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp.Time)
+	})
+	for _, trade := range sorted {
+		price := trade.Price
+		switch trade.OrderType {
+		case "BUY":
+			book.Sell = consumeBook(book.Sell, trade.Quantity, func(rate decimal.Decimal) bool {
+				return rate.LessThanOrEqual(price)
+			})
+		case "SELL":
+			book.Buy = consumeBook(book.Buy, trade.Quantity, func(rate decimal.Decimal) bool {
+				return rate.GreaterThanOrEqual(price)
+			})
+		}
+	}
+	return book
+}
+
+// consumeBook removes quantity from the levels of side, best first, while they are reachable
+func consumeBook(side []Orderb, quantity decimal.Decimal, reachable func(rate decimal.Decimal) bool) []Orderb {
+	for len(side) > 0 && quantity.IsPositive() && reachable(side[0].Rate) {
+		if side[0].Quantity.GreaterThan(quantity) {
+			side[0].Quantity = side[0].Quantity.Sub(quantity)
+			break
+		}
+		quantity = quantity.Sub(side[0].Quantity)
+		side = side[1:]
+	}
+	return side
+}
+
+// DepthPoint is a level of a depth chart
+type DepthPoint struct {
+	Rate       decimal.Decimal
+	Quantity   decimal.Decimal
+	Cumulative decimal.Decimal // quantity available from the best rate to this one
+}
+
+// DepthChartData holds the cumulative sides of an order book, ready to be charted.
+// Both sides start at the best rate and go away from the spread, which lies between
+// Bids[0] and Asks[0].
+type DepthChartData struct {
+	Bids   []DepthPoint // highest rate first
+	Asks   []DepthPoint // lowest rate first
+	Mid    decimal.Decimal
+	Spread decimal.Decimal
+}
+
+// DepthChart returns the levels best levels (all of them if levels <= 0) of each side
+// of the order book with their cumulative quantities.
+// Mid and Spread are zero if a side is empty.
+func (ob OrderBook) DepthChart(levels int) (chart DepthChartData) {
+	chart.Bids = depthPoints(ob.Buy, levels, func(a, b decimal.Decimal) bool { return a.GreaterThan(b) })
+	chart.Asks = depthPoints(ob.Sell, levels, func(a, b decimal.Decimal) bool { return a.LessThan(b) })
+	chart.Mid, chart.Spread = ob.MidPrice(), ob.Spread()
+	return
+}
+
+// depthPoints sorts side, best rate first according to better, and accumulates its quantities
+func depthPoints(side []Orderb, levels int, better func(a, b decimal.Decimal) bool) []DepthPoint {
+	sorted := append([]Orderb(nil), side...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return better(sorted[i].Rate, sorted[j].Rate)
+	})
+	if levels > 0 && levels < len(sorted) {
+		sorted = sorted[:levels]
+	}
+	points := make([]DepthPoint, len(sorted))
+	var cumulative decimal.Decimal
+	for i, entry := range sorted {
+		cumulative = cumulative.Add(entry.Quantity)
+		points[i] = DepthPoint{Rate: entry.Rate, Quantity: entry.Quantity, Cumulative: cumulative}
+	}
+	return points
+}
+
+// AverageFillPrice returns the volume weighted average price at which a market order
+// of quantity on side would be filled, walking the order book from the best rate
+// (a buy consumes the sell side). ErrInsufficientDepth is returned if the book
+// does not hold enough quantity.
+func (ob OrderBook) AverageFillPrice(side OrderSide, quantity decimal.Decimal) (price decimal.Decimal, err error) {
+	if !quantity.IsPositive() {
+		return price, fmt.Errorf("quantity must be positive, got %s", quantity)
+	}
+	chart := ob.DepthChart(0)
+	levels := chart.Asks
+	if side == SELL {
+		levels = chart.Bids
+	}
+	remaining, cost := quantity, decimal.Decimal{}
+	for _, level := range levels {
+		filled := decimal.Min(remaining, level.Quantity)
+		cost = cost.Add(filled.Mul(level.Rate))
+		remaining = remaining.Sub(filled)
+		if remaining.IsZero() {
+			return cost.Div(quantity), nil
+		}
+	}
+	return price, ErrInsufficientDepth
+}
+
+// ImpactBps returns the cost, in basis points of the mid price, of filling a market
+// order of quantity on side instead of trading at the mid price.
+// ErrInsufficientDepth is returned if the book does not hold enough quantity, or if
+// one of its sides is empty, as there is no mid price then.
+func (ob OrderBook) ImpactBps(side OrderSide, quantity decimal.Decimal) (impact decimal.Decimal, err error) {
+	price, err := ob.AverageFillPrice(side, quantity)
+	if err != nil {
+		return
+	}
+	mid := ob.MidPrice()
+	if mid.IsZero() {
+		return impact, fmt.Errorf("%w: no mid price with a side of the book empty", ErrInsufficientDepth)
+	}
+	impact = price.Sub(mid).Div(mid).Mul(decimal.New(10000, 0))
+	if side == SELL {
+		impact = impact.Neg()
+	}
+	return impact, nil
+}
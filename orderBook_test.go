@@ -0,0 +1,174 @@
+package bittrex
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func testOrderBook() OrderBook {
+	entry := func(quantity, rate string) Orderb {
+		return Orderb{Quantity: decimal.RequireFromString(quantity), Rate: decimal.RequireFromString(rate)}
+	}
+	return OrderBook{
+		Buy:  []Orderb{entry("1", "0.99"), entry("1", "0.97")},
+		Sell: []Orderb{entry("2", "1.01"), entry("2", "1.03")},
+	}
+}
+
+func TestOrderBookImpactBps(t *testing.T) {
+	tests := []struct {
+		side     OrderSide
+		quantity string
+		price    string
+		impact   string
+	}{
+		{BUY, "1", "1.01", "100"},
+		{BUY, "4", "1.02", "200"},
+		{SELL, "1", "0.99", "100"},
+		{SELL, "2", "0.98", "200"},
+	}
+	ob := testOrderBook()
+	for _, test := range tests {
+		quantity := decimal.RequireFromString(test.quantity)
+		price, err := ob.AverageFillPrice(test.side, quantity)
+		if err != nil {
+			t.Fatalf("%s %s: %v", test.side, test.quantity, err)
+		}
+		if !price.Equal(decimal.RequireFromString(test.price)) {
+			t.Errorf("%s %s: expected price %s, got %s", test.side, test.quantity, test.price, price)
+		}
+		impact, err := ob.ImpactBps(test.side, quantity)
+		if err != nil {
+			t.Fatalf("%s %s: %v", test.side, test.quantity, err)
+		}
+		if !impact.Equal(decimal.RequireFromString(test.impact)) {
+			t.Errorf("%s %s: expected impact %s bps, got %s", test.side, test.quantity, test.impact, impact)
+		}
+	}
+}
+
+func TestOrderBookImpactBpsInsufficientDepth(t *testing.T) {
+	if _, err := testOrderBook().ImpactBps(BUY, decimal.New(5, 0)); err != ErrInsufficientDepth {
+		t.Errorf("expected ErrInsufficientDepth, got %v", err)
+	}
+}
+
+func TestOrderBookImpactBpsOneSided(t *testing.T) {
+	ob := testOrderBook()
+	ob.Buy = nil
+	if _, err := ob.ImpactBps(BUY, decimal.New(1, 0)); !errors.Is(err, ErrInsufficientDepth) {
+		t.Errorf("sell only book: expected ErrInsufficientDepth, got %v", err)
+	}
+	ob = testOrderBook()
+	ob.Sell = nil
+	if _, err := ob.ImpactBps(SELL, decimal.New(1, 0)); !errors.Is(err, ErrInsufficientDepth) {
+		t.Errorf("buy only book: expected ErrInsufficientDepth, got %v", err)
+	}
+}
+
+func TestOrderBookBestPrices(t *testing.T) {
+	ob := OrderBook{
+		Buy:  []Orderb{{Quantity: decimal.New(1, 0), Rate: decimal.New(98, 0)}, {Quantity: decimal.New(1, 0), Rate: decimal.New(99, 0)}},
+		Sell: []Orderb{{Quantity: decimal.New(1, 0), Rate: decimal.New(102, 0)}, {Quantity: decimal.New(1, 0), Rate: decimal.New(101, 0)}},
+	}
+	bid, okBid := ob.BestBid()
+	ask, okAsk := ob.BestAsk()
+	if !okBid || !okAsk || bid.Rate.String() != "99" || ask.Rate.String() != "101" {
+		t.Errorf("unexpected best bid %v (%v) and ask %v (%v)", bid, okBid, ask, okAsk)
+	}
+	if ob.Spread().String() != "2" || ob.MidPrice().String() != "100" {
+		t.Errorf("unexpected spread %s and mid price %s", ob.Spread(), ob.MidPrice())
+	}
+
+	// a book fetched with cat "buy" has no sell side
+	ob.Sell = nil
+	if _, ok := ob.BestAsk(); ok {
+		t.Error("expected no best ask")
+	}
+	if !ob.Spread().IsZero() || !ob.MidPrice().IsZero() {
+		t.Errorf("expected zero spread and mid price, got %s and %s", ob.Spread(), ob.MidPrice())
+	}
+}
+
+func TestReconstructBook(t *testing.T) {
+	d := decimal.RequireFromString
+	at := func(minute int) jTime {
+		return jTime{time.Date(2020, 1, 1, 0, minute, 0, 0, time.UTC)}
+	}
+	snapshot := testOrderBook()
+	trades := []Trade{
+		// applied after the one at minute 1, though listed first
+		{Timestamp: at(2), OrderType: "BUY", Quantity: d("1"), Price: d("1.01")},
+		// consumes the first sell level and 0.5 of the second, within its price
+		{Timestamp: at(1), OrderType: "BUY", Quantity: d("2.5"), Price: d("1.03")},
+		// stops at its price limit of 0.98: the 0.97 level is left
+		{Timestamp: at(3), OrderType: "SELL", Quantity: d("5"), Price: d("0.98")},
+	}
+	book := ReconstructBook(snapshot, trades)
+
+	// the BUY at 1.01 finds no sell level left at its price
+	if len(book.Sell) != 1 || book.Sell[0].Rate.String() != "1.03" || book.Sell[0].Quantity.String() != "1.5" {
+		t.Errorf("unexpected sell side %+v", book.Sell)
+	}
+	if len(book.Buy) != 1 || book.Buy[0].Rate.String() != "0.97" || book.Buy[0].Quantity.String() != "1" {
+		t.Errorf("unexpected buy side %+v", book.Buy)
+	}
+
+	// a partially consumed level is not changed in the snapshot
+	book = ReconstructBook(snapshot, []Trade{{OrderType: "SELL", Quantity: d("0.4"), Price: d("0.99")}})
+	if book.Buy[0].Quantity.String() != "0.6" {
+		t.Errorf("expected 0.6 left at 0.99, got %+v", book.Buy)
+	}
+	if snapshot.Buy[0].Quantity.String() != "1" || len(snapshot.Buy) != 2 || len(snapshot.Sell) != 2 || snapshot.Sell[0].Quantity.String() != "2" {
+		t.Errorf("the snapshot was modified: %+v", snapshot)
+	}
+}
+
+func TestDepthChart(t *testing.T) {
+	entry := func(quantity, rate int64) Orderb {
+		return Orderb{Quantity: decimal.New(quantity, 0), Rate: decimal.New(rate, 0)}
+	}
+	// unsorted sides
+	ob := OrderBook{
+		Buy:  []Orderb{entry(1, 9), entry(2, 10), entry(3, 8)},
+		Sell: []Orderb{entry(1, 12), entry(2, 11), entry(4, 13)},
+	}
+	points := func(side []DepthPoint) string {
+		s := ""
+		for _, p := range side {
+			s += fmt.Sprintf("%s:%s:%s ", p.Rate, p.Quantity, p.Cumulative)
+		}
+		return s
+	}
+
+	// both sides accumulate away from the spread
+	chart := ob.DepthChart(0)
+	if bids := points(chart.Bids); bids != "10:2:2 9:1:3 8:3:6 " {
+		t.Errorf("unexpected bids %s", bids)
+	}
+	if asks := points(chart.Asks); asks != "11:2:2 12:1:3 13:4:7 " {
+		t.Errorf("unexpected asks %s", asks)
+	}
+	if chart.Mid.String() != "10.5" || chart.Spread.String() != "1" {
+		t.Errorf("unexpected mid %s and spread %s", chart.Mid, chart.Spread)
+	}
+
+	// the best levels are kept
+	chart = ob.DepthChart(2)
+	if bids, asks := points(chart.Bids), points(chart.Asks); bids != "10:2:2 9:1:3 " || asks != "11:2:2 12:1:3 " {
+		t.Errorf("unexpected truncated bids %s and asks %s", bids, asks)
+	}
+	if ob.Buy[0].Rate.String() != "9" {
+		t.Errorf("the order book was sorted in place: %+v", ob.Buy)
+	}
+
+	ob.Sell = nil
+	chart = ob.DepthChart(0)
+	if len(chart.Asks) != 0 || len(chart.Bids) != 3 || !chart.Mid.IsZero() || !chart.Spread.IsZero() {
+		t.Errorf("unexpected chart of a buy only book %+v", chart)
+	}
+}
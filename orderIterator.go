@@ -0,0 +1,93 @@
+package bittrex
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// OrderIterator iterates over an order history, fetching it lazily page by page.
+type OrderIterator struct {
+	nextPage func() (orders []Order, more bool, err error)
+	page     []Order
+	more     bool
+	err      error
+}
+
+// OrderHistoryIterator returns an iterator over your order history, most recently closed
+// first. market string literal for the market (ie. BTC-LTC). If set to "all", will iterate
+// over all markets.
+// Nothing is fetched before the first call to Next. The v1.1 API does not paginate the
+// order history, so the closed orders of the v3 API are fetched instead, V3_MAX_PAGE_SIZE
+// orders per request, and returned in the v1.1 shape.
+func (b *Bittrex) OrderHistoryIterator(market string) *OrderIterator {
+	params := url.Values{"pageSize": {strconv.Itoa(V3_MAX_PAGE_SIZE)}}
+	it := &OrderIterator{more: true}
+	if market != "all" {
+		base, quote, err := SplitMarket(market)
+		if err != nil {
+			it.err = err
+			return it
+		}
+		params.Set("marketSymbol", FormatMarket(quote, base))
+	}
+	it.nextPage = func() ([]Order, bool, error) {
+		r, err := b.client.doV3("GET", API_V3_BASE+"orders/closed?"+params.Encode(), "")
+		if err != nil {
+			return nil, false, decodeV3Error(r, err)
+		}
+		var page []V3Order
+		if err = b.decoder.Unmarshal(r, &page); err != nil {
+			return nil, false, fmt.Errorf("could not unmarshal orders/closed: %v", err)
+		}
+		orders := make([]Order, len(page))
+		for i, order := range page {
+			orders[i] = order.toOrder()
+		}
+		if len(page) < V3_MAX_PAGE_SIZE {
+			return orders, false, nil
+		}
+		params.Set("nextPageToken", page[len(page)-1].Id)
+		return orders, true, nil
+	}
+	return it
+}
+
+// toOrder converts o to the v1.1 shape of the order history
+func (o V3Order) toOrder() Order {
+	order := Order{
+		OrderUuid:         o.Id,
+		TimeStamp:         jTime{o.CreatedAt},
+		OrderType:         o.Type + "_" + o.Direction,
+		Limit:             o.Limit,
+		Quantity:          o.Quantity,
+		QuantityRemaining: o.Quantity.Sub(o.FillQuantity),
+		Commission:        o.Commission,
+		Price:             o.Proceeds,
+	}
+	if i := strings.IndexByte(o.MarketSymbol, '-'); i >= 0 {
+		order.Exchange = FormatMarket(o.MarketSymbol[i+1:], o.MarketSymbol[:i])
+	}
+	if !o.FillQuantity.IsZero() {
+		perUnit := o.Proceeds.Div(o.FillQuantity)
+		order.PricePerUnit = &perUnit
+	}
+	if o.ClosedAt != nil {
+		order.Closed = &jTime{*o.ClosedAt}
+	}
+	return order
+}
+
+// Next returns the next order of the history.
+// ok is false once the history is exhausted or if fetching a page failed.
+func (it *OrderIterator) Next() (order Order, ok bool, err error) {
+	for len(it.page) == 0 {
+		if it.err != nil || !it.more {
+			return order, false, it.err
+		}
+		it.page, it.more, it.err = it.nextPage()
+	}
+	order, it.page = it.page[0], it.page[1:]
+	return order, true, nil
+}
@@ -0,0 +1,9 @@
+package bittrex
+
+// OrderSide is the side of an order, buy or sell
+type OrderSide string
+
+const (
+	BUY  OrderSide = "buy"
+	SELL OrderSide = "sell"
+)
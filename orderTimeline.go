@@ -0,0 +1,61 @@
+package bittrex
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type OrderEventType string
+
+const (
+	ORDER_EVENT_SUBMITTED        OrderEventType = "submitted"
+	ORDER_EVENT_PARTIALLY_FILLED OrderEventType = "partially_filled"
+	ORDER_EVENT_FILLED           OrderEventType = "filled"
+	ORDER_EVENT_CANCELED         OrderEventType = "canceled"
+)
+
+// OrderEvent is a step of the lifecycle of an order
+type OrderEvent struct {
+	Type              OrderEventType
+	Time              time.Time
+	QuantityRemaining decimal.Decimal
+}
+
+// OrderTimeline returns the lifecycle of an order as events in chronological order.
+// The v1.1 API only exposes the open and close times of an order, not its
+// individual executions, so the timeline is derived from them:
+//
+//	an order is always submitted at its open time,
+//	a partially filled open order gets a partially_filled event at the time of the call,
+//	a partially filled canceled order gets a partially_filled event at its close time,
+//	a closed order is filled, or canceled if some quantity remains, at its close time.
+func (b *Bittrex) OrderTimeline(uuid string) (events []OrderEvent, err error) {
+	order, err := b.GetOrder(uuid)
+	if err != nil {
+		return
+	}
+	events = append(events, OrderEvent{ORDER_EVENT_SUBMITTED, order.Opened.Time, order.Quantity})
+
+	partiallyFilled := order.QuantityRemaining.LessThan(order.Quantity) && order.QuantityRemaining.IsPositive()
+	if order.IsOpen {
+		if partiallyFilled {
+			events = append(events, OrderEvent{ORDER_EVENT_PARTIALLY_FILLED, b.client.now(), order.QuantityRemaining})
+		}
+		return
+	}
+
+	var closed time.Time
+	if order.Closed != nil {
+		closed = order.Closed.Time
+	}
+	if order.QuantityRemaining.IsZero() {
+		events = append(events, OrderEvent{ORDER_EVENT_FILLED, closed, order.QuantityRemaining})
+		return
+	}
+	if partiallyFilled {
+		events = append(events, OrderEvent{ORDER_EVENT_PARTIALLY_FILLED, closed, order.QuantityRemaining})
+	}
+	events = append(events, OrderEvent{ORDER_EVENT_CANCELED, closed, order.QuantityRemaining})
+	return
+}
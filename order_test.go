@@ -0,0 +1,265 @@
+package bittrex
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestOrderUnmarshalNull(t *testing.T) {
+	data := `{
+		"OrderUuid": "fd97d393-e9b9-4dd1-9dbf-f288fc72a185",
+		"Exchange": "BTC-LTC",
+		"TimeStamp": "2014-07-09T04:01:00.667",
+		"OrderType": "MARKET_BUY",
+		"Limit": null,
+		"Quantity": 1.00000000,
+		"QuantityRemaining": 1.00000000,
+		"Commission": 0.00000000,
+		"Price": 0.00000000,
+		"PricePerUnit": null
+	}`
+	var order Order
+	if err := json.Unmarshal([]byte(data), &order); err != nil {
+		t.Fatal(err)
+	}
+	if order.Limit != nil {
+		t.Errorf("Limit: expected nil, got %s", order.Limit)
+	}
+	if order.PricePerUnit != nil {
+		t.Errorf("PricePerUnit: expected nil, got %s", order.PricePerUnit)
+	}
+	if order.TimeStamp.IsZero() {
+		t.Error("TimeStamp: expected a time, got zero")
+	}
+}
+
+func TestOrder2UnmarshalNull(t *testing.T) {
+	data := `{
+		"OrderUuid": "0cb4c4e4-bdc7-4e13-8c13-430e587d2cc1",
+		"Exchange": "BTC-SHLD",
+		"Type": "LIMIT_BUY",
+		"Quantity": 1000.00000000,
+		"QuantityRemaining": 1000.00000000,
+		"Limit": 0.00000001,
+		"PricePerUnit": null,
+		"Opened": "2014-07-13T07:45:46.27",
+		"Closed": null,
+		"IsOpen": true,
+		"IsConditional": false,
+		"Condition": "NONE",
+		"ConditionTarget": null
+	}`
+	var order Order2
+	if err := json.Unmarshal([]byte(data), &order); err != nil {
+		t.Fatal(err)
+	}
+	if order.Limit == nil || order.Limit.String() != "0.00000001" {
+		t.Errorf("Limit: expected 0.00000001, got %v", order.Limit)
+	}
+	if order.PricePerUnit != nil {
+		t.Errorf("PricePerUnit: expected nil, got %s", order.PricePerUnit)
+	}
+	if order.Closed != nil {
+		t.Errorf("Closed: expected nil, got %s", order.Closed)
+	}
+	if order.ConditionTarget != nil {
+		t.Errorf("ConditionTarget: expected nil, got %s", order.ConditionTarget)
+	}
+	if order.Opened.IsZero() {
+		t.Error("Opened: expected a time, got zero")
+	}
+}
+
+func TestJTimeUnmarshalNull(t *testing.T) {
+	var w Withdrawal
+	if err := json.Unmarshal([]byte(`{"Opened": null}`), &w); err != nil {
+		t.Fatal(err)
+	}
+	if !w.Opened.IsZero() {
+		t.Errorf("Opened: expected zero time, got %s", w.Opened)
+	}
+}
+
+func TestGetOpenOrdersError(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":false,"message":"APIKEY_INVALID","result":null}`
+	})
+	if _, err := bt.GetOpenOrders("BTC-LTC"); err == nil || err.Error() != "APIKEY_INVALID" {
+		t.Errorf("GetOpenOrders: expected APIKEY_INVALID, got %v", err)
+	}
+	if _, err := bt.GetOrder("abc"); err == nil || err.Error() != "APIKEY_INVALID" {
+		t.Errorf("GetOrder: expected APIKEY_INVALID, got %v", err)
+	}
+}
+
+func TestGetOpenOrdersAll(t *testing.T) {
+	var query url.Values
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		query = req.URL.Query()
+		return http.StatusOK, `{"success":true,"message":"","result":[{"OrderUuid":"a","Exchange":"BTC-LTC"},{"OrderUuid":"b","Exchange":"USDT-BTC"}]}`
+	})
+	orders, err := bt.GetOpenOrders("all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := query["market"]; ok {
+		t.Errorf("expected no market parameter, got %q", query.Get("market"))
+	}
+	if len(orders) != 2 || orders[0].Exchange != "BTC-LTC" || orders[1].Exchange != "USDT-BTC" {
+		t.Errorf("expected the orders of both markets, got %+v", orders)
+	}
+
+	if _, err := bt.GetOpenOrders("btc-ltc"); err != nil {
+		t.Fatal(err)
+	}
+	if m := query.Get("market"); m != "BTC-LTC" {
+		t.Errorf("market: expected BTC-LTC, got %q", m)
+	}
+}
+
+func TestNullResult(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":true,"message":"","result":null}`
+	})
+	if err := bt.CancelOrder("abc"); err != nil {
+		t.Errorf("CancelOrder: expected no error, got %v", err)
+	}
+	if orders, err := bt.GetOpenOrders("all"); err != nil || len(orders) != 0 {
+		t.Errorf("GetOpenOrders: expected no orders and no error, got %v, %v", orders, err)
+	}
+	if order, err := bt.GetOrder("abc"); err != nil || order.OrderUuid != "" {
+		t.Errorf("GetOrder: expected a zero order and no error, got %+v, %v", order, err)
+	}
+	if _, err := bt.BuyLimit("BTC-LTC", decimal.New(1, 0), decimal.New(1, 0)); err != ErrNullResult {
+		t.Errorf("BuyLimit: expected ErrNullResult, got %v", err)
+	}
+	if _, err := bt.Withdraw("addr", "BTC", decimal.New(1, 0)); err != ErrNullResult {
+		t.Errorf("Withdraw: expected ErrNullResult, got %v", err)
+	}
+}
+
+func TestGetOrderHistoryRange(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":true,"message":"","result":[
+			{"OrderUuid":"c","TimeStamp":"2020-03-01T10:00:00","Closed":"2020-03-02T10:00:00"},
+			{"OrderUuid":"b","TimeStamp":"2020-02-01T10:00:00","Closed":"2020-02-03T10:00:00"},
+			{"OrderUuid":"a","TimeStamp":"2020-01-31T10:00:00","Closed":"2020-02-01T00:00:00"},
+			{"OrderUuid":"z","TimeStamp":"2020-01-01T10:00:00","Closed":"2020-01-02T10:00:00"}]}`
+	})
+	from := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 3, 2, 10, 0, 0, 0, time.UTC)
+	orders, err := bt.GetOrderHistoryRange("all", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var uuids []string
+	for _, order := range orders {
+		uuids = append(uuids, order.OrderUuid)
+	}
+	if strings.Join(uuids, ",") != "a,b,c" {
+		t.Errorf("expected a,b,c, got %v", uuids)
+	}
+}
+
+func TestOrderFillHelpers(t *testing.T) {
+	ppu := decimal.RequireFromString("0.02")
+	tests := []struct {
+		name                          string
+		order                         Order
+		filled, netCost, averagePrice string
+		isFilled                      bool
+	}{
+		{"filled buy", Order{OrderType: "LIMIT_BUY", Quantity: decimal.New(2, 0), Price: decimal.RequireFromString("0.04"), Commission: decimal.RequireFromString("0.0001"), PricePerUnit: &ppu},
+			"2", "0.0401", "0.02", true},
+		{"partial sell", Order{OrderType: "LIMIT_SELL", Quantity: decimal.New(4, 0), QuantityRemaining: decimal.New(3, 0), Price: decimal.RequireFromString("0.03"), Commission: decimal.RequireFromString("0.0001")},
+			"1", "0.0299", "0.03", false},
+		{"unfilled", Order{OrderType: "LIMIT_BUY", Quantity: decimal.New(1, 0), QuantityRemaining: decimal.New(1, 0)},
+			"0", "0", "0", false},
+		{"zero quantity", Order{OrderType: "LIMIT_BUY"},
+			"0", "0", "0", false},
+	}
+	for _, test := range tests {
+		o := test.order
+		if got := o.FilledQuantity().String(); got != test.filled {
+			t.Errorf("%s: FilledQuantity: expected %s, got %s", test.name, test.filled, got)
+		}
+		if got := o.IsFilled(); got != test.isFilled {
+			t.Errorf("%s: IsFilled: expected %v, got %v", test.name, test.isFilled, got)
+		}
+		if got := o.NetCost().String(); got != test.netCost {
+			t.Errorf("%s: NetCost: expected %s, got %s", test.name, test.netCost, got)
+		}
+		if got := o.AveragePrice().String(); got != test.averagePrice {
+			t.Errorf("%s: AveragePrice: expected %s, got %s", test.name, test.averagePrice, got)
+		}
+	}
+}
+
+func TestOrderHistoryIterator(t *testing.T) {
+	order := func(i int) string {
+		return fmt.Sprintf(`{"id":"o%d","marketSymbol":"LTC-BTC","direction":"SELL","type":"LIMIT","quantity":"2","limit":"0.01","fillQuantity":"1","commission":"0.0001","proceeds":"0.012","status":"CLOSED","createdAt":"2018-01-01T00:00:00Z","closedAt":"2018-01-02T00:00:00Z"}`, i)
+	}
+	var tokens []string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		q := req.URL.Query()
+		if req.URL.Path != "/v3/orders/closed" || q.Get("marketSymbol") != "LTC-BTC" || q.Get("pageSize") != "200" {
+			t.Errorf("unexpected request %s", req.URL)
+		}
+		tokens = append(tokens, q.Get("nextPageToken"))
+		var page []string
+		switch q.Get("nextPageToken") {
+		case "":
+			for i := V3_MAX_PAGE_SIZE; i > 0; i-- {
+				page = append(page, order(i))
+			}
+		case "o1":
+			page = append(page, order(0))
+		}
+		return http.StatusOK, "[" + strings.Join(page, ",") + "]"
+	})
+	it := bt.OrderHistoryIterator("btc-ltc")
+	if len(tokens) != 0 {
+		t.Fatal("expected nothing fetched before Next")
+	}
+	var orders []Order
+	for {
+		order, ok, err := it.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			break
+		}
+		orders = append(orders, order)
+	}
+	if strings.Join(tokens, ",") != ",o1" {
+		t.Errorf("expected a second page after o1, got page tokens %q", tokens)
+	}
+	if len(orders) != V3_MAX_PAGE_SIZE+1 || orders[len(orders)-1].OrderUuid != "o0" {
+		t.Fatalf("expected %d orders ending with o0, got %d", V3_MAX_PAGE_SIZE+1, len(orders))
+	}
+	o := orders[0]
+	if o.OrderUuid != "o200" || o.Exchange != "BTC-LTC" || o.OrderType != "LIMIT_SELL" || o.QuantityRemaining.String() != "1" ||
+		o.Price.String() != "0.012" || o.PricePerUnit == nil || o.PricePerUnit.String() != "0.012" || o.Closed == nil || o.Closed.Day() != 2 {
+		t.Errorf("unexpected order %+v", o)
+	}
+}
+
+func TestOrderHistoryIteratorError(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusUnauthorized, `{"code":"APIKEY_INVALID"}`
+	})
+	if _, ok, err := bt.OrderHistoryIterator("all").Next(); ok || !IsAPIError(err) {
+		t.Errorf("expected an APIError, got %v, %v", ok, err)
+	}
+	if _, ok, err := bt.OrderHistoryIterator("BTCLTC").Next(); ok || err == nil {
+		t.Errorf("expected an invalid market error, got %v, %v", ok, err)
+	}
+}
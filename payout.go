@@ -0,0 +1,61 @@
+package bittrex
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Payout is a withdrawal to make with ScheduleWithdrawals
+type Payout struct {
+	Address  string
+	Quantity decimal.Decimal
+}
+
+// ScheduleWithdrawals withdraws the payouts of currency, in order, without exceeding
+// dailyLimit over the current UTC day. The quantity already withdrawn today, by this
+// process or another one, is read from the withdrawal history.
+// A payout which would exceed the limit is deferred and the next ones are still tried.
+// It returns the uuids of the withdrawals made and the deferred payouts, to schedule
+// again the next day. On error (including ctx being done), the payouts not processed
+// yet are returned as deferred too.
+func (b *Bittrex) ScheduleWithdrawals(ctx context.Context, currency string, payouts []Payout, dailyLimit decimal.Decimal) (withdrawUuids []string, deferred []Payout, err error) {
+	currency = strings.ToUpper(currency)
+	withdrawn, err := b.withdrawnSince(currency, b.client.now().UTC().Truncate(24*time.Hour))
+	if err != nil {
+		return nil, payouts, err
+	}
+	for i, payout := range payouts {
+		if err = ctx.Err(); err != nil {
+			return withdrawUuids, append(deferred, payouts[i:]...), err
+		}
+		if withdrawn.Add(payout.Quantity).GreaterThan(dailyLimit) {
+			deferred = append(deferred, payout)
+			continue
+		}
+		var uuid string
+		if uuid, err = b.Withdraw(payout.Address, currency, payout.Quantity); err != nil {
+			return withdrawUuids, append(deferred, payouts[i:]...), err
+		}
+		withdrawUuids = append(withdrawUuids, uuid)
+		withdrawn = withdrawn.Add(payout.Quantity)
+	}
+	return
+}
+
+// withdrawnSince returns the quantity of currency withdrawn since t, canceled withdrawals excluded
+func (b *Bittrex) withdrawnSince(currency string, t time.Time) (total decimal.Decimal, err error) {
+	withdrawals, err := b.GetWithdrawalHistory(currency)
+	if err != nil {
+		return
+	}
+	for _, w := range withdrawals {
+		if w.Canceled || w.Currency != currency || w.Opened.Before(t) {
+			continue
+		}
+		total = total.Add(w.Amount)
+	}
+	return
+}
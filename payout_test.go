@@ -0,0 +1,96 @@
+package bittrex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// payoutTestBittrex returns a client whose withdrawal history holds history, a JSON array,
+// and whose withdrawals to the address "fail" are rejected. The addresses withdrawn to are
+// appended to withdrawn.
+func payoutTestBittrex(history string, withdrawn *[]string) *Bittrex {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if strings.HasSuffix(req.URL.Path, "/account/getwithdrawalhistory") {
+			return http.StatusOK, `{"success":true,"message":"","result":` + history + `}`
+		}
+		address := req.URL.Query().Get("address")
+		if address == "fail" {
+			return http.StatusOK, `{"success":false,"message":"WITHDRAWAL_TOO_SMALL","result":null}`
+		}
+		*withdrawn = append(*withdrawn, address)
+		return http.StatusOK, `{"success":true,"message":"","result":{"uuid":"w-` + address + `"}}`
+	})
+	bt.SetClock(func() time.Time { return time.Date(2020, 3, 10, 12, 0, 0, 0, time.UTC) })
+	return bt
+}
+
+func payouts(spec ...string) (payouts []Payout) {
+	for _, s := range spec {
+		parts := strings.Split(s, ":")
+		payouts = append(payouts, Payout{Address: parts[0], Quantity: decimal.RequireFromString(parts[1])})
+	}
+	return
+}
+
+func TestScheduleWithdrawals(t *testing.T) {
+	// only the 0.5 BTC withdrawn today counts: yesterday's and the canceled one do not
+	history := `[
+		{"PaymentUuid":"p1","Currency":"BTC","Amount":0.5,"Opened":"2020-03-10T01:00:00"},
+		{"PaymentUuid":"p2","Currency":"BTC","Amount":1,"Opened":"2020-03-09T23:00:00"},
+		{"PaymentUuid":"p3","Currency":"BTC","Amount":2,"Opened":"2020-03-10T02:00:00","Canceled":true}]`
+	var withdrawn []string
+	bt := payoutTestBittrex(history, &withdrawn)
+
+	uuids, deferred, err := bt.ScheduleWithdrawals(context.Background(), "btc", payouts("a:0.3", "b:0.5", "c:0.2"), decimal.New(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(uuids) != "[w-a w-c]" || fmt.Sprint(withdrawn) != "[a c]" {
+		t.Errorf("expected withdrawals to a and c, got %v", uuids)
+	}
+	if len(deferred) != 1 || deferred[0].Address != "b" {
+		t.Errorf("expected the payout to b deferred, got %+v", deferred)
+	}
+}
+
+func TestScheduleWithdrawalsErrors(t *testing.T) {
+	var withdrawn []string
+	bt := payoutTestBittrex(`[]`, &withdrawn)
+	limit := decimal.New(10, 0)
+
+	// a failed withdrawal defers it and the payouts after it
+	uuids, deferred, err := bt.ScheduleWithdrawals(context.Background(), "BTC", payouts("a:1", "fail:1", "c:1"), limit)
+	if !IsAPIError(err) {
+		t.Errorf("expected an APIError, got %v", err)
+	}
+	if fmt.Sprint(uuids) != "[w-a]" || len(deferred) != 2 || deferred[0].Address != "fail" || deferred[1].Address != "c" {
+		t.Errorf("expected a withdrawn, fail and c deferred, got %v, %+v", uuids, deferred)
+	}
+
+	// a done ctx defers every payout
+	withdrawn = nil
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	uuids, deferred, err = bt.ScheduleWithdrawals(ctx, "BTC", payouts("a:1", "b:1"), limit)
+	if err != context.Canceled || len(uuids) != 0 || len(deferred) != 2 || len(withdrawn) != 0 {
+		t.Errorf("expected every payout deferred with context.Canceled, got %v, %+v, %v", uuids, deferred, err)
+	}
+
+	// so does a failure to read the withdrawal history
+	bt = newTestBittrex(func(req *http.Request) (int, string) {
+		if !strings.HasSuffix(req.URL.Path, "/account/getwithdrawalhistory") {
+			t.Errorf("unexpected request %s", req.URL)
+		}
+		return http.StatusOK, `{"success":false,"message":"APIKEY_INVALID","result":null}`
+	})
+	uuids, deferred, err = bt.ScheduleWithdrawals(context.Background(), "BTC", payouts("a:1", "b:1"), limit)
+	if !IsAPIError(err) || len(uuids) != 0 || len(deferred) != 2 {
+		t.Errorf("expected every payout deferred with an APIError, got %v, %+v, %v", uuids, deferred, err)
+	}
+}
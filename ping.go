@@ -0,0 +1,45 @@
+package bittrex
+
+import (
+	"errors"
+	"fmt"
+)
+
+// v3Ping is the response of the v3 ping endpoint
+type v3Ping struct {
+	ServerTime int64 `json:"serverTime"` // milliseconds since epoch
+}
+
+// Ping checks that Bittrex API is reachable, through its lightweight v3 ping endpoint.
+// It returns nil if Bittrex answered with its server time. Ex, as a startup preflight:
+//
+//	if err := b.Ping(); err != nil {
+//		log.Fatalf("Bittrex API unreachable: %v", err)
+//	}
+func (b *Bittrex) Ping() error {
+	r, err := b.doer.do("GET", API_V3_BASE+"ping", "", false)
+	if err != nil {
+		return fmt.Errorf("ping failed: %w", err)
+	}
+	var ping v3Ping
+	if err = b.decoder.Unmarshal(r, &ping); err != nil {
+		return fmt.Errorf("ping failed: %w", err)
+	}
+	if ping.ServerTime == 0 {
+		return errors.New("ping failed: no server time in response")
+	}
+	return nil
+}
+
+// PingAuth is like Ping for authenticated calls: it checks that Bittrex API is reachable
+// and accepts the API key and secret, by retrieving the balances. An *APIError (ex:
+// APIKEY_INVALID, INVALID_SIGNATURE) is returned for rejected credentials. Balances which
+// could not be decoded are not an error here: Bittrex accepted the credentials to send them.
+func (b *Bittrex) PingAuth() error {
+	_, err := b.GetBalances()
+	var errs MultiError
+	if errors.As(err, &errs) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,61 @@
+package bittrex
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket pacing the requests to Bittrex API.
+// It uses the real time, not the client clock, as it waits on real timers (see SetClock).
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // maximum number of tokens
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns a rate limiter allowing requestsPerSecond requests per second,
+// with bursts of up to requestsPerSecond requests (at least 1).
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	burst := requestsPerSecond
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{rate: requestsPerSecond, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait blocks until a request is allowed or ctx is done. A nil rate limiter allows
+// all requests.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		// give back the token reserved by this request
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,27 @@
+package bittrex
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(20)
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		if err := limiter.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a burst of 20 requests, then 10 more at 20 per second
+	if elapsed := time.Since(start); elapsed < 450*time.Millisecond {
+		t.Errorf("30 requests at 20 per second took only %s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.wait(ctx); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
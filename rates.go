@@ -0,0 +1,127 @@
+package bittrex
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// PriceSource selects the price of a market used by rate computations
+type PriceSource string
+
+const (
+	PRICE_LAST PriceSource = "last" // last trade price
+	PRICE_MID  PriceSource = "mid"  // middle of the best bid and ask
+)
+
+// CROSS_CURRENCY is the currency through which cross rates are computed
+// when two currencies have no market in common
+const CROSS_CURRENCY = "BTC"
+
+// CrossRate returns the quantity of currency to one unit of currency from is worth.
+// It uses the market between both currencies if it exists, or goes through
+// CROSS_CURRENCY otherwise. Prices are the last trade price or the mid price of
+// the markets, according to source. An error is returned if no path exists.
+func (b *Bittrex) CrossRate(from, to string, source PriceSource) (rate decimal.Decimal, err error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return decimal.New(1, 0), nil
+	}
+	summaries, err := b.GetMarketSummaries()
+	if err != nil {
+		return
+	}
+	prices := make(map[string]decimal.Decimal, len(summaries))
+	for _, s := range summaries {
+		price := s.Last
+		if source == PRICE_MID {
+			price = s.Bid.Add(s.Ask).Div(decimal.New(2, 0))
+		}
+		if price.IsPositive() {
+			prices[s.MarketName] = price
+		}
+	}
+
+	if rate, ok := directRate(prices, from, to); ok {
+		return rate, nil
+	}
+	toCross, ok1 := directRate(prices, from, CROSS_CURRENCY)
+	fromCross, ok2 := directRate(prices, CROSS_CURRENCY, to)
+	if !ok1 || !ok2 {
+		return rate, fmt.Errorf("no market path from %s to %s", from, to)
+	}
+	return toCross.Mul(fromCross), nil
+}
+
+// directRate returns the rate from currency from to currency to using the market between them.
+// A market BASE-CUR is priced in BASE, so one CUR is worth price BASE.
+func directRate(prices map[string]decimal.Decimal, from, to string) (decimal.Decimal, bool) {
+	if price, ok := prices[FormatMarket(to, from)]; ok {
+		return price, true
+	}
+	if price, ok := prices[FormatMarket(from, to)]; ok {
+		return decimal.New(1, 0).Div(price), true
+	}
+	return decimal.Decimal{}, false
+}
+
+// BestMarketToBuy returns, among the markets of currency quoted in quoteOptions (ex: BTC,
+// ETH, USDT), the one with the lowest ask once converted to CROSS_CURRENCY at the last
+// trade prices, and this converted ask.
+func (b *Bittrex) BestMarketToBuy(currency string, quoteOptions []string) (market string, price decimal.Decimal, err error) {
+	return b.bestMarket(currency, quoteOptions, BUY)
+}
+
+// BestMarketToSell returns, among the markets of currency quoted in quoteOptions, the one
+// with the highest bid once converted to CROSS_CURRENCY at the last trade prices, and
+// this converted bid.
+func (b *Bittrex) BestMarketToSell(currency string, quoteOptions []string) (market string, price decimal.Decimal, err error) {
+	return b.bestMarket(currency, quoteOptions, SELL)
+}
+
+// bestMarket returns the market of currency offering the best price to trade on side
+func (b *Bittrex) bestMarket(currency string, quoteOptions []string, side OrderSide) (market string, price decimal.Decimal, err error) {
+	summaries, err := b.GetMarketSummaries()
+	if err != nil {
+		return
+	}
+	currency = strings.ToUpper(currency)
+	last := make(map[string]decimal.Decimal, len(summaries))
+	quotes := make(map[string]MarketSummary, len(summaries))
+	for _, s := range summaries {
+		if s.Last.IsPositive() {
+			last[s.MarketName] = s.Last
+		}
+		quotes[s.MarketName] = s
+	}
+
+	for _, quote := range quoteOptions {
+		quote = strings.ToUpper(quote)
+		s, ok := quotes[FormatMarket(quote, currency)]
+		if !ok {
+			continue
+		}
+		p := s.Ask
+		if side == SELL {
+			p = s.Bid
+		}
+		if !p.IsPositive() {
+			continue
+		}
+		if quote != CROSS_CURRENCY {
+			rate, ok := directRate(last, quote, CROSS_CURRENCY)
+			if !ok {
+				continue
+			}
+			p = p.Mul(rate)
+		}
+		if market == "" || side == BUY && p.LessThan(price) || side == SELL && p.GreaterThan(price) {
+			market, price = s.MarketName, p
+		}
+	}
+	if market == "" {
+		err = fmt.Errorf("no market for %s quoted in %s", currency, strings.Join(quoteOptions, ", "))
+	}
+	return
+}
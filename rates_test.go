@@ -0,0 +1,90 @@
+package bittrex
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func newRatesBittrex(summaries string) *Bittrex {
+	return newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":true,"message":"","result":` + summaries + `}`
+	})
+}
+
+func TestCrossRate(t *testing.T) {
+	bt := newRatesBittrex(`[
+		{"MarketName":"BTC-LTC","Last":0.01,"Bid":0.009,"Ask":0.012},
+		{"MarketName":"USDT-BTC","Last":10000,"Bid":9990,"Ask":10010},
+		{"MarketName":"BTC-DOGE","Last":0,"Bid":0,"Ask":0}]`)
+	tests := []struct {
+		from, to string
+		source   PriceSource
+		rate     string
+	}{
+		{"LTC", "LTC", PRICE_LAST, "1"},
+		// direct market BTC-LTC, priced in BTC
+		{"ltc", "btc", PRICE_LAST, "0.01"},
+		// inverted market
+		{"BTC", "LTC", PRICE_LAST, "100"},
+		{"BTC", "USDT", PRICE_LAST, "10000"},
+		{"USDT", "BTC", PRICE_LAST, "0.0001"},
+		// through BTC
+		{"LTC", "USDT", PRICE_LAST, "100"},
+		{"USDT", "LTC", PRICE_LAST, "0.01"},
+		// middle of 0.009 and 0.012, and of 9990 and 10010
+		{"LTC", "BTC", PRICE_MID, "0.0105"},
+		{"LTC", "USDT", PRICE_MID, "105"},
+	}
+	for _, test := range tests {
+		rate, err := bt.CrossRate(test.from, test.to, test.source)
+		if err != nil {
+			t.Errorf("%s to %s (%s): %v", test.from, test.to, test.source, err)
+			continue
+		}
+		if !rate.Equal(decimal.RequireFromString(test.rate)) {
+			t.Errorf("%s to %s (%s): expected %s, got %s", test.from, test.to, test.source, test.rate, rate)
+		}
+	}
+
+	// no market, and a market without price
+	for _, from := range []string{"XMR", "DOGE"} {
+		if _, err := bt.CrossRate(from, "USDT", PRICE_LAST); err == nil {
+			t.Errorf("%s to USDT: expected an error for no path", from)
+		}
+	}
+}
+
+func TestBestMarket(t *testing.T) {
+	// converted to BTC at the last prices of BTC-ETH and of USDT-BTC, inverted:
+	// BTC-LTC ask 0.012 bid 0.009, ETH-LTC ask 0.01 bid 0.0095, USDT-LTC ask 0.011 bid 0.01
+	bt := newRatesBittrex(`[
+		{"MarketName":"BTC-LTC","Last":0.01,"Bid":0.009,"Ask":0.012},
+		{"MarketName":"ETH-LTC","Last":0.2,"Bid":0.19,"Ask":0.2},
+		{"MarketName":"USDT-LTC","Last":105,"Bid":100,"Ask":110},
+		{"MarketName":"EUR-LTC","Last":1,"Bid":1,"Ask":1},
+		{"MarketName":"BTC-ETH","Last":0.05,"Bid":0.04,"Ask":0.06},
+		{"MarketName":"USDT-BTC","Last":10000,"Bid":9000,"Ask":11000}]`)
+	quotes := []string{"btc", "ETH", "USDT", "EUR", "XMR"}
+
+	market, price, err := bt.BestMarketToBuy("ltc", quotes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if market != "ETH-LTC" || !price.Equal(decimal.RequireFromString("0.01")) {
+		t.Errorf("BestMarketToBuy: expected ETH-LTC at 0.01, got %s at %s", market, price)
+	}
+	market, price, err = bt.BestMarketToSell("LTC", quotes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if market != "USDT-LTC" || !price.Equal(decimal.RequireFromString("0.01")) {
+		t.Errorf("BestMarketToSell: expected USDT-LTC at 0.01, got %s at %s", market, price)
+	}
+
+	// EUR has no market with BTC to convert its price, XMR no market with LTC
+	if _, _, err = bt.BestMarketToBuy("LTC", []string{"EUR", "XMR"}); err == nil {
+		t.Error("expected an error with no convertible market")
+	}
+}
@@ -0,0 +1,71 @@
+package bittrex
+
+import (
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"testing"
+)
+
+// Code depending on this package can be tested offline the same way: responses
+// recorded with NewRecordingClient, or written by hand, are served by NewReplayClient.
+func TestReplayClient(t *testing.T) {
+	bt := NewReplayClient("testdata/replay")
+
+	markets, err := bt.GetMarkets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(markets) != 2 || markets[0].MarketName != "BTC-LTC" || markets[1].MinTradeSize.String() != "0.005" {
+		t.Errorf("unexpected markets %+v", markets)
+	}
+
+	ticker, err := bt.GetTicker("btc-ltc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticker.Bid.String() != "0.01185" || ticker.Ask.String() != "0.01186513" {
+		t.Errorf("unexpected ticker %+v", ticker)
+	}
+
+	if _, err := bt.GetTicker("BTC-ETH"); err == nil {
+		t.Error("expected an error for a request without recorded response")
+	}
+}
+
+// fixtureDoer is a doer serving the responses saved in dir, named as by a recording
+// client, without HTTP
+type fixtureDoer struct {
+	dir string
+}
+
+func (d fixtureDoer) do(method string, resource string, payload string, authNeeded bool) ([]byte, error) {
+	u, err := url.Parse(API_BASE + API_VERSION + "/" + resource)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(filepath.Join(d.dir, replayKey(u)))
+}
+
+func TestFixtureDoer(t *testing.T) {
+	bt := New("", "")
+	bt.doer = fixtureDoer{"testdata/replay"}
+
+	markets, err := bt.GetMarkets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(markets) != 2 || markets[0].MarketName != "BTC-LTC" {
+		t.Errorf("unexpected markets %+v", markets)
+	}
+	ticker, err := bt.GetTicker("btc-ltc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticker.Bid.String() != "0.01185" {
+		t.Errorf("unexpected ticker %+v", ticker)
+	}
+	if _, err := bt.GetTicker("BTC-ETH"); err == nil {
+		t.Error("expected an error for a request without fixture")
+	}
+}
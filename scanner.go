@@ -0,0 +1,113 @@
+package bittrex
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// NewMarketsSince returns the markets created after t, sorted by creation date (oldest first).
+func (b *Bittrex) NewMarketsSince(t time.Time) (markets []Market, err error) {
+	all, err := b.GetMarkets()
+	if err != nil {
+		return
+	}
+	for _, market := range all {
+		if market.Created.After(t) {
+			markets = append(markets, market)
+		}
+	}
+	sort.Slice(markets, func(i, j int) bool {
+		return markets[i].Created.Before(markets[j].Created.Time)
+	})
+	return
+}
+
+// MarketLiquidity measures the liquidity of a market
+type MarketLiquidity struct {
+	MarketName string
+	SpreadBps  decimal.Decimal // bid-ask spread in basis points of the mid price
+	BaseVolume decimal.Decimal // 24h volume in base currency
+	Score      int             // composite score, the lower the more liquid
+}
+
+// RankMarketsByLiquidity returns the n most liquid markets (all of them if n <= 0),
+// most liquid first. Markets are ranked twice, by tightest spread and by highest base
+// volume, and their Score is the sum of both ranks (starting at 1). Ties are broken
+// by volume. Markets without bid or ask are ignored.
+func (b *Bittrex) RankMarketsByLiquidity(n int) (ranking []MarketLiquidity, err error) {
+	summaries, err := b.GetMarketSummaries()
+	if err != nil {
+		return
+	}
+	bps := decimal.New(10000, 0)
+	for _, s := range summaries {
+		if !s.Bid.IsPositive() || !s.Ask.IsPositive() {
+			continue
+		}
+		mid := s.Bid.Add(s.Ask).Div(decimal.New(2, 0))
+		ranking = append(ranking, MarketLiquidity{
+			MarketName: s.MarketName,
+			SpreadBps:  s.Ask.Sub(s.Bid).Div(mid).Mul(bps),
+			BaseVolume: s.BaseVolume,
+		})
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return ranking[i].SpreadBps.LessThan(ranking[j].SpreadBps)
+	})
+	for i := range ranking {
+		ranking[i].Score = i + 1
+	}
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return ranking[i].BaseVolume.GreaterThan(ranking[j].BaseVolume)
+	})
+	for i := range ranking {
+		ranking[i].Score += i + 1
+	}
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return ranking[i].Score < ranking[j].Score
+	})
+
+	if n > 0 && n < len(ranking) {
+		ranking = ranking[:n]
+	}
+	return
+}
+
+// MarketMove is the 24h price change of a market
+type MarketMove struct {
+	MarketName    string
+	Last          decimal.Decimal
+	PrevDay       decimal.Decimal
+	PercentChange decimal.Decimal // see MarketSummary.PercentChange
+}
+
+// MoversAbove returns the markets whose 24h price change exceeds percentThreshold
+// in absolute value, largest moves first. Markets without a PrevDay price are ignored.
+func (b *Bittrex) MoversAbove(percentThreshold float64) (moves []MarketMove, err error) {
+	summaries, err := b.GetMarketSummaries()
+	if err != nil {
+		return
+	}
+	threshold := decimal.NewFromFloat(percentThreshold).Abs()
+	for _, s := range summaries {
+		if s.PrevDay.IsZero() {
+			continue
+		}
+		change := s.PercentChange()
+		if change.Abs().GreaterThan(threshold) {
+			moves = append(moves, MarketMove{
+				MarketName:    s.MarketName,
+				Last:          s.Last,
+				PrevDay:       s.PrevDay,
+				PercentChange: change,
+			})
+		}
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		return moves[i].PercentChange.Abs().GreaterThan(moves[j].PercentChange.Abs())
+	})
+	return
+}
@@ -0,0 +1,56 @@
+package bittrex
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRankMarketsByLiquidity(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":true,"message":"","result":[
+			{"MarketName":"BTC-A","Bid":99,"Ask":101,"BaseVolume":10},
+			{"MarketName":"BTC-B","Bid":9.9,"Ask":10.1,"BaseVolume":30},
+			{"MarketName":"BTC-C","Bid":0.999,"Ask":1.001,"BaseVolume":5},
+			{"MarketName":"BTC-D","Bid":0,"Ask":1,"BaseVolume":1000},
+			{"MarketName":"BTC-E","Bid":49,"Ask":51,"BaseVolume":20}]}`
+	})
+
+	// spread ranks: C (20 bps), A and B (200 bps, in order), E (400 bps);
+	// volume ranks: B, E, A, C; D has no bid
+	ranking, err := bt.RankMarketsByLiquidity(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		name  string
+		score int
+	}{
+		{"BTC-B", 4},
+		// tied with C, A has the highest volume
+		{"BTC-A", 5},
+		{"BTC-C", 5},
+		{"BTC-E", 6},
+	}
+	if len(ranking) != len(expected) {
+		t.Fatalf("expected %d markets, got %+v", len(expected), ranking)
+	}
+	for i, e := range expected {
+		if ranking[i].MarketName != e.name || ranking[i].Score != e.score {
+			t.Errorf("rank %d: expected %s scoring %d, got %s scoring %d", i+1, e.name, e.score, ranking[i].MarketName, ranking[i].Score)
+		}
+	}
+	if ranking[0].SpreadBps.String() != "200" || ranking[0].BaseVolume.String() != "30" {
+		t.Errorf("unexpected liquidity of BTC-B %+v", ranking[0])
+	}
+
+	top, err := bt.RankMarketsByLiquidity(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 2 || top[0].MarketName != "BTC-B" || top[1].MarketName != "BTC-A" {
+		t.Errorf("expected the top 2 BTC-B and BTC-A, got %+v", top)
+	}
+	if all, _ := bt.RankMarketsByLiquidity(10); len(all) != 4 {
+		t.Errorf("expected all 4 markets for n above their count, got %+v", all)
+	}
+}
@@ -0,0 +1,88 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// State is a snapshot of the account and of some markets
+type State struct {
+	Balances   []Balance
+	OpenOrders []Order
+	Tickers    map[string]Ticker // indexed by market
+}
+
+// MarketState fetches concurrently the balances, the open orders and the tickers of markets,
+// the tickers with at most the maximum concurrency (see SetMaxConcurrency) requests at a time.
+// If some of the requests fail, the partial state is returned along with a MultiError.
+// If ctx is done before all the requests complete, the requests in flight are aborted and
+// a nil state and ctx error are returned.
+func (b *Bittrex) MarketState(ctx context.Context, markets []string) (*State, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	state := &State{}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs MultiError
+	)
+	addErr := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}
+
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		balances, err := b.GetBalances(WithContext(ctx))
+		state.Balances = balances
+		var balanceErrs MultiError
+		if errors.As(err, &balanceErrs) {
+			// keep the balances which could be decoded
+			for _, err := range balanceErrs {
+				addErr(fmt.Errorf("balances: %v", err))
+			}
+		} else if err != nil {
+			addErr(fmt.Errorf("balances: %v", err))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		orders, err := b.GetOpenOrders("all", WithContext(ctx))
+		if err != nil {
+			addErr(fmt.Errorf("open orders: %v", err))
+			return
+		}
+		state.OpenOrders = orders
+	}()
+	go func() {
+		defer wg.Done()
+		tickers, err := b.GetTickers(markets, WithContext(ctx))
+		state.Tickers = tickers
+		var tickerErrs MultiError
+		if errors.As(err, &tickerErrs) {
+			for _, err := range tickerErrs {
+				addErr(fmt.Errorf("ticker %v", err))
+			}
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if len(errs) > 0 {
+		return state, errs
+	}
+	return state, nil
+}
@@ -0,0 +1,73 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMarketState(t *testing.T) {
+	var tickersRunning, maxTickersRunning int32
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/account/getbalances"):
+			return http.StatusOK, `{"success":true,"message":"","result":[{"Currency":"BTC","Balance":1}]}`
+		case strings.HasSuffix(req.URL.Path, "/market/getopenorders"):
+			return http.StatusOK, `{"success":true,"message":"","result":[{"OrderUuid":"abc"}]}`
+		}
+		n := atomic.AddInt32(&tickersRunning, 1)
+		defer atomic.AddInt32(&tickersRunning, -1)
+		if n > atomic.LoadInt32(&maxTickersRunning) {
+			atomic.StoreInt32(&maxTickersRunning, n)
+		}
+		time.Sleep(5 * time.Millisecond)
+		if req.URL.Query().Get("market") == "BTC-BAD" {
+			return http.StatusOK, `{"success":false,"message":"INVALID_MARKET","result":null}`
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":{"Bid":1,"Ask":2,"Last":1.5}}`
+	})
+	bt.SetMaxConcurrency(1)
+
+	state, err := bt.MarketState(context.Background(), []string{"BTC-LTC", "BTC-BAD", "BTC-ETH"})
+	if state == nil {
+		t.Fatalf("expected a partial state, got %v", err)
+	}
+	if len(state.Balances) != 1 || len(state.OpenOrders) != 1 || len(state.Tickers) != 2 {
+		t.Errorf("unexpected state %+v", state)
+	}
+	var errs MultiError
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Error() != "ticker BTC-BAD: INVALID_MARKET" {
+		t.Errorf("expected the failure of BTC-BAD, got %v", err)
+	}
+	if maxTickersRunning > 1 {
+		t.Errorf("expected at most 1 concurrent ticker request, got %d", maxTickersRunning)
+	}
+}
+
+func TestMarketStateCanceled(t *testing.T) {
+	var running int32
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		select {
+		case <-req.Context().Done():
+		case <-time.After(5 * time.Second):
+			t.Errorf("request %s not aborted", req.URL)
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":[]}`
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if state, err := bt.MarketState(ctx, []string{"BTC-LTC", "BTC-ETH"}); state != nil || err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %+v, %v", state, err)
+	}
+	for deadline := time.Now().Add(time.Second); atomic.LoadInt32(&running) > 0; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("requests still running after cancel")
+		}
+	}
+}
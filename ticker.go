@@ -1,9 +1,124 @@
 package bittrex
 
-import "github.com/shopspring/decimal"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// Sources of a ticker returned by GetTickerResilient
+const (
+	TICKER_SOURCE_TICKER    = "ticker"
+	TICKER_SOURCE_SUMMARY   = "summary"
+	TICKER_SOURCE_ORDERBOOK = "orderbook"
+)
 
 type Ticker struct {
 	Bid  decimal.Decimal `json:"Bid"`
 	Ask  decimal.Decimal `json:"Ask"`
 	Last decimal.Decimal `json:"Last"`
+	// Source is set by GetTickerResilient to the endpoint the ticker comes from
+	Source string `json:"-"`
+}
+
+// GetTickerResilient is used to get the current ticker values for a market,
+// falling back on other endpoints when one fails. Sources are tried in this order:
+//
+//	TICKER_SOURCE_TICKER: public/getticker, sets Bid, Ask and Last.
+//	TICKER_SOURCE_SUMMARY: public/getmarketsummary, sets Bid, Ask and Last.
+//	TICKER_SOURCE_ORDERBOOK: best entries of public/getorderbook, sets Bid and Ask only, Last is zero.
+//
+// The error of the last source is returned if all of them fail.
+func (b *Bittrex) GetTickerResilient(market string) (ticker Ticker, err error) {
+	if ticker, err = b.GetTicker(market); err == nil {
+		ticker.Source = TICKER_SOURCE_TICKER
+		return
+	}
+
+	if s, err := b.GetMarketSummary(market); err == nil {
+		return Ticker{Bid: s.Bid, Ask: s.Ask, Last: s.Last, Source: TICKER_SOURCE_SUMMARY}, nil
+	}
+
+	orderBook, err := b.GetOrderBook(market, "both", 1)
+	if err != nil {
+		return Ticker{}, fmt.Errorf("could not get ticker from any source: %w", err)
+	}
+	bid, okBid := orderBook.BestBid()
+	ask, okAsk := orderBook.BestAsk()
+	if !okBid || !okAsk {
+		return Ticker{}, errors.New("could not get ticker from any source: empty order book")
+	}
+	return Ticker{Bid: bid.Rate, Ask: ask.Rate, Source: TICKER_SOURCE_ORDERBOOK}, nil
+}
+
+// GetTickers fetches concurrently the tickers of markets, with at most the maximum
+// concurrency (see SetMaxConcurrency) requests at a time, and returns them by market.
+// opts apply to each request: with WithContext, the markets not fetched yet once the
+// context is done are skipped. If some of the requests fail, the tickers fetched are
+// returned along with a MultiError of the failures.
+func (b *Bittrex) GetTickers(markets []string, opts ...CallOption) (tickers map[string]Ticker, err error) {
+	tickers = make(map[string]Ticker, len(markets))
+	var mu sync.Mutex
+	err = b.forEachMarket(newCallOptions(opts).ctx, markets, func(market string) error {
+		ticker, err := b.GetTicker(market, opts...)
+		if err == nil {
+			mu.Lock()
+			tickers[market] = ticker
+			mu.Unlock()
+		}
+		return err
+	})
+	return
+}
+
+// forEachMarket calls fetch for each of markets, concurrently with at most the maximum
+// concurrency (see SetMaxConcurrency) calls at a time. The markets not fetched yet once
+// ctx is done are skipped. The failures, and the skipped markets, are returned as a
+// MultiError of errors prefixed by their market, nil if there are none.
+func (b *Bittrex) forEachMarket(ctx context.Context, markets []string, fetch func(market string) error) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs MultiError
+	)
+	jobs := make(chan string)
+	workers := b.maxConcurrency
+	if workers > len(markets) {
+		workers = len(markets)
+	}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for market := range jobs {
+				if err := fetch(market); err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("%s: %w", market, err))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+feed:
+	for i, market := range markets {
+		select {
+		case jobs <- market:
+		case <-ctx.Done():
+			mu.Lock()
+			for _, skipped := range markets[i:] {
+				errs = append(errs, fmt.Errorf("%s: %w", skipped, ctx.Err()))
+			}
+			mu.Unlock()
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
 }
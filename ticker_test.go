@@ -0,0 +1,109 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetTickers(t *testing.T) {
+	var running, maxRunning int32
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if req.URL.Query().Get("market") == "BTC-BAD" {
+			return http.StatusOK, `{"success":false,"message":"INVALID_MARKET","result":null}`
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":{"Bid":1,"Ask":2,"Last":1.5}}`
+	})
+	bt.SetMaxConcurrency(2)
+
+	markets := []string{"BTC-LTC", "BTC-ETH", "BTC-BAD", "BTC-XRP", "BTC-ADA"}
+	tickers, err := bt.GetTickers(markets)
+	if len(tickers) != 4 || tickers["BTC-LTC"].Last.String() != "1.5" {
+		t.Errorf("expected 4 tickers, got %v", tickers)
+	}
+	var errs MultiError
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Errorf("expected the failure of BTC-BAD, got %v", err)
+	}
+	if maxRunning > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxRunning)
+	}
+}
+
+// The failures of the workers and the markets skipped once ctx is done are gathered
+// concurrently: run with -race.
+func TestGetTickersCanceled(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		time.Sleep(5 * time.Millisecond)
+		return http.StatusOK, `{"success":false,"message":"INVALID_MARKET","result":null}`
+	})
+	bt.SetMaxConcurrency(2)
+
+	markets := []string{"BTC-A", "BTC-B", "BTC-C", "BTC-D", "BTC-E", "BTC-F", "BTC-G", "BTC-H"}
+	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Millisecond)
+	defer cancel()
+	tickers, err := bt.GetTickers(markets, WithContext(ctx))
+	var errs MultiError
+	if len(tickers) != 0 || !errors.As(err, &errs) || len(errs) != len(markets) {
+		t.Fatalf("expected an error per market, got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected skipped markets, got %v", err)
+	}
+}
+
+func TestGetTickerResilient(t *testing.T) {
+	failing := map[string]bool{}
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		path := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+		if failing[path] {
+			return http.StatusOK, `{"success":false,"message":"INVALID_MARKET","result":null}`
+		}
+		switch path {
+		case "getticker":
+			return http.StatusOK, `{"success":true,"message":"","result":{"Bid":1,"Ask":2,"Last":1.5}}`
+		case "getmarketsummary":
+			return http.StatusOK, `{"success":true,"message":"","result":[{"MarketName":"BTC-LTC","Bid":3,"Ask":4,"Last":3.5}]}`
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":{"buy":[{"Quantity":1,"Rate":5}],"sell":[{"Quantity":1,"Rate":6}]}}`
+	})
+	for _, c := range []struct {
+		failing  []string
+		expected string
+	}{
+		{nil, "ticker 1 2 1.5"},
+		{[]string{"getticker"}, "summary 3 4 3.5"},
+		{[]string{"getticker", "getmarketsummary"}, "orderbook 5 6 0"},
+	} {
+		failing = map[string]bool{}
+		for _, path := range c.failing {
+			failing[path] = true
+		}
+		ticker, err := bt.GetTickerResilient("BTC-LTC")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprintf("%s %s %s %s", ticker.Source, ticker.Bid, ticker.Ask, ticker.Last); got != c.expected {
+			t.Errorf("failing %v: expected %s, got %s", c.failing, c.expected, got)
+		}
+	}
+
+	failing = map[string]bool{"getticker": true, "getmarketsummary": true, "getorderbook": true}
+	if _, err := bt.GetTickerResilient("BTC-LTC"); !IsAPIError(err) || !errors.Is(err, ErrInvalidMarket) {
+		t.Errorf("expected the APIError of the order book, got %v", err)
+	}
+}
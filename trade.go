@@ -1,6 +1,16 @@
 package bittrex
 
-import "github.com/shopspring/decimal"
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrNoTrades is returned when no trade happened in the requested period
+var ErrNoTrades = errors.New("no trades in period")
 
 // Used in getmarkethistory
 type Trade struct {
@@ -12,3 +22,73 @@ type Trade struct {
 	FillType  string          `json:"FillType"`
 	OrderType string          `json:"OrderType"`
 }
+
+// GetMarketHistoryMulti fetches concurrently the latest trades of markets, with at most the
+// maximum concurrency (see SetMaxConcurrency) requests at a time, and returns them by market.
+// Each slice keeps the order of GetMarketHistory (most recent first), truncated to the count
+// most recent trades if count is positive. One request is made per market, each counting
+// against the rate limit (see SetRateLimit).
+// opts apply to each request: with WithContext, the markets not fetched yet once the
+// context is done are skipped. If some of the requests fail, the trades fetched are
+// returned along with a MultiError of the failures.
+func (b *Bittrex) GetMarketHistoryMulti(markets []string, count int, opts ...CallOption) (trades map[string][]Trade, err error) {
+	trades = make(map[string][]Trade, len(markets))
+	var mu sync.Mutex
+	err = b.forEachMarket(newCallOptions(opts).ctx, markets, func(market string) error {
+		history, err := b.GetMarketHistory(market, opts...)
+		if err != nil {
+			return err
+		}
+		if count > 0 && len(history) > count {
+			history = history[:count]
+		}
+		mu.Lock()
+		trades[market] = history
+		mu.Unlock()
+		return nil
+	})
+	return
+}
+
+// TWAP returns the time weighted average price of market over the last window: each
+// trade price is weighted by the time it remained the last price, until the next trade
+// or now. ErrNoTrades is returned if no trade happened during window.
+func (b *Bittrex) TWAP(market string, window time.Duration) (twap decimal.Decimal, err error) {
+	history, err := b.GetMarketHistory(market)
+	if err != nil {
+		return
+	}
+	now := b.client.now()
+	start := now.Add(-window)
+	var trades []Trade
+	for _, trade := range history {
+		if !trade.Timestamp.Before(start) && !trade.Timestamp.After(now) {
+			trades = append(trades, trade)
+		}
+	}
+	if len(trades) == 0 {
+		return twap, ErrNoTrades
+	}
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].Timestamp.Before(trades[j].Timestamp.Time)
+	})
+
+	var weighted, total decimal.Decimal
+	for i, trade := range trades {
+		end := now
+		if i+1 < len(trades) {
+			end = trades[i+1].Timestamp.Time
+		}
+		weight := decimal.New(int64(end.Sub(trade.Timestamp.Time)), 0)
+		weighted = weighted.Add(trade.Price.Mul(weight))
+		total = total.Add(weight)
+	}
+	if total.IsZero() {
+		// all trades happened now, fall back to their plain average
+		for _, trade := range trades {
+			weighted = weighted.Add(trade.Price)
+		}
+		total = decimal.New(int64(len(trades)), 0)
+	}
+	return weighted.Div(total), nil
+}
@@ -0,0 +1,60 @@
+package bittrex
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTWAP(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":true,"message":"","result":[
+			{"Id":3,"TimeStamp":"2020-01-01T00:09:00","Quantity":1,"Price":130},
+			{"Id":2,"TimeStamp":"2020-01-01T00:06:00","Quantity":1,"Price":100},
+			{"Id":1,"TimeStamp":"2020-01-01T00:00:00","Quantity":1,"Price":50}]}`
+	})
+	bt.SetClock(func() time.Time { return time.Date(2020, 1, 1, 0, 10, 0, 0, time.UTC) })
+
+	// 100 for 3 minutes then 130 for 1 minute, the first trade is out of the window
+	twap, err := bt.TWAP("BTC-LTC", 5*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if twap.String() != "107.5" {
+		t.Errorf("expected 107.5, got %s", twap)
+	}
+
+	if _, err := bt.TWAP("BTC-LTC", 30*time.Second); err != ErrNoTrades {
+		t.Errorf("expected ErrNoTrades, got %v", err)
+	}
+}
+
+func TestGetMarketHistoryMulti(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		switch req.URL.Query().Get("market") {
+		case "BTC-BAD":
+			return http.StatusOK, `{"success":false,"message":"INVALID_MARKET","result":null}`
+		case "BTC-ETH":
+			return http.StatusOK, `{"success":true,"message":"","result":[{"Id":7,"Price":0.03}]}`
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":[{"Id":3,"Price":0.01},{"Id":2,"Price":0.02},{"Id":1,"Price":0.03}]}`
+	})
+	bt.SetMaxConcurrency(2)
+
+	trades, err := bt.GetMarketHistoryMulti([]string{"BTC-LTC", "BTC-ETH", "BTC-BAD"}, 2)
+	if len(trades) != 2 {
+		t.Fatalf("expected the trades of 2 markets, got %v", trades)
+	}
+	if ltc := trades["BTC-LTC"]; len(ltc) != 2 || ltc[0].OrderUuid != 3 || ltc[1].OrderUuid != 2 {
+		t.Errorf("expected the 2 most recent trades in order, got %+v", ltc)
+	}
+	if len(trades["BTC-ETH"]) != 1 {
+		t.Errorf("expected 1 trade, got %+v", trades["BTC-ETH"])
+	}
+	var errs MultiError
+	if !errors.As(err, &errs) || len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), "BTC-BAD: ") {
+		t.Errorf("expected the failure of BTC-BAD, got %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// BuyingPower returns the maximum quantity which can be bought on market at the current
+// ask with the available balance of the base currency (ex: BTC for BTC-LTC), commission
+// included. It is floored to the market quantity step.
+func (b *Bittrex) BuyingPower(market string) (maxQuantity decimal.Decimal, err error) {
+	m, err := b.getMarket(market)
+	if err != nil {
+		return
+	}
+	balance, err := b.GetBalance(m.BaseCurrency)
+	if err != nil {
+		return
+	}
+	ticker, err := b.GetTicker(market)
+	if err != nil {
+		return
+	}
+	if !ticker.Ask.IsPositive() {
+		return maxQuantity, fmt.Errorf("no ask on %s", m.MarketName)
+	}
+	unitCost := ticker.Ask.Mul(decimal.New(1, 0).Add(b.commissionRate))
+	return b.RoundQuantityToStep(market, balance.Available.Div(unitCost))
+}
+
+// PassiveLimitPrice returns a price the given number of ticks better than the best price on side
+// (above the best bid for a buy, below the best ask for a sell), to join the queue near
+// the top of the book. The price is capped one tick away from the opposite best price
+// so the order never crosses the spread.
+func (b *Bittrex) PassiveLimitPrice(market string, side OrderSide, ticks int) (price decimal.Decimal, err error) {
+	m, err := b.getMarket(market)
+	if err != nil {
+		return
+	}
+	orderBook, err := b.GetOrderBook(market, "both", 1)
+	if err != nil {
+		return
+	}
+	bestBid, okBid := orderBook.BestBid()
+	bestAsk, okAsk := orderBook.BestAsk()
+	if !okBid || !okAsk {
+		return price, fmt.Errorf("empty order book on %s", market)
+	}
+	bid, ask := bestBid.Rate, bestAsk.Rate
+	tick := m.PriceTick()
+	offset := tick.Mul(decimal.New(int64(ticks), 0))
+	switch side {
+	case BUY:
+		price = decimal.Min(bid.Add(offset), ask.Sub(tick))
+		price = decimal.Max(price, bid)
+	case SELL:
+		price = decimal.Max(ask.Sub(offset), bid.Add(tick))
+		price = decimal.Min(price, ask)
+	default:
+		err = fmt.Errorf("unknown order side %q", side)
+	}
+	return
+}
+
+// checkSelfTrade returns ErrWouldSelfTrade if an order on side at rate would match
+// one of your open orders on market.
+func (b *Bittrex) checkSelfTrade(market string, side OrderSide, rate decimal.Decimal) error {
+	orders, err := b.GetOpenOrders(market)
+	if err != nil {
+		return err
+	}
+	for _, order := range orders {
+		if order.Limit == nil {
+			continue
+		}
+		if side == BUY && strings.HasSuffix(order.OrderType, "SELL") && rate.GreaterThanOrEqual(*order.Limit) ||
+			side == SELL && strings.HasSuffix(order.OrderType, "BUY") && rate.LessThanOrEqual(*order.Limit) {
+			return fmt.Errorf("%w: %s at %s", ErrWouldSelfTrade, order.OrderUuid, order.Limit)
+		}
+	}
+	return nil
+}
+
+// WaitForOrder polls an order every pollInterval until it is closed, and returns it.
+// Use IsFilled and IsCanceled on the returned order to tell how it closed. Requests go
+// through the rate limiter (see SetRateLimit) like any other. If ctx is done first,
+// the last state of the order is returned with ctx.Err(). ErrInvalidPollInterval is
+// returned for a pollInterval which is not positive.
+func (b *Bittrex) WaitForOrder(ctx context.Context, uuid string, pollInterval time.Duration) (order Order2, err error) {
+	if pollInterval <= 0 {
+		return order, ErrInvalidPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		if order, err = b.GetOrder(uuid); err != nil || !order.IsOpen {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return order, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForFill polls an order every pollInterval until it is closed, and returns it.
+// If the order is closed without being fully filled, ErrOrderCanceled is returned.
+func (b *Bittrex) WaitForFill(ctx context.Context, uuid string, pollInterval time.Duration) (order Order2, err error) {
+	if order, err = b.WaitForOrder(ctx, uuid, pollInterval); err == nil && order.IsCanceled() {
+		err = ErrOrderCanceled
+	}
+	return
+}
+
+// placeLimit places a limit order on side
+func (b *Bittrex) placeLimit(market string, side OrderSide, quantity, rate decimal.Decimal) (string, error) {
+	if side == SELL {
+		return b.SellLimit(market, quantity, rate)
+	}
+	return b.BuyLimit(market, quantity, rate)
+}
+
+// placeMarket places a market order on side of quantity on market
+func (b *Bittrex) placeMarket(market string, side OrderSide, quantity decimal.Decimal) (string, error) {
+	if side == SELL {
+		return b.SellMarket(market, quantity)
+	}
+	return b.BuyMarket(market, quantity)
+}
+
+// MarketImpactBps returns the cost, in basis points of the mid price, of filling a market
+// order of quantity on side in the current order book of market (see OrderBook.ImpactBps).
+// ErrInsufficientDepth is returned if the order book does not hold enough quantity or has an empty side.
+func (b *Bittrex) MarketImpactBps(market string, side OrderSide, quantity decimal.Decimal) (impact decimal.Decimal, err error) {
+	orderBook, err := b.GetOrderBook(market, "both", 0)
+	if err != nil {
+		return
+	}
+	return orderBook.ImpactBps(side, quantity)
+}
+
+// BuyMarketMaxSlippage buys quantity on market at the current ask side of the order book,
+// provided the projected slippage of the average fill price over the best ask does not
+// exceed maxSlippageBps basis points. Otherwise ErrSlippageTooHigh is returned and no
+// order is placed. Rather than a market order (see BuyMarket), which would fill at
+// whatever the book holds once it reaches Bittrex, a limit order is placed at the worst
+// rate needed to fill quantity in the book read, so the slippage stays bounded.
+func (b *Bittrex) BuyMarketMaxSlippage(market string, quantity decimal.Decimal, maxSlippageBps float64) (uuid string, err error) {
+	orderBook, err := b.GetOrderBook(market, "sell", 0)
+	if err != nil {
+		return
+	}
+	price, err := orderBook.AverageFillPrice(BUY, quantity)
+	if err != nil {
+		return
+	}
+	asks := orderBook.DepthChart(0).Asks
+	best, worst := asks[0].Rate, asks[0].Rate
+	for _, level := range asks {
+		worst = level.Rate
+		if level.Cumulative.GreaterThanOrEqual(quantity) {
+			break
+		}
+	}
+	slippage := price.Sub(best).Div(best).Mul(decimal.New(10000, 0))
+	if slippage.GreaterThan(decimal.NewFromFloat(maxSlippageBps)) {
+		return "", fmt.Errorf("%w: %s bps", ErrSlippageTooHigh, slippage.StringFixed(2))
+	}
+	return b.BuyLimit(market, quantity, worst)
+}
+
+// CancelAllOrders cancels your open orders on market (or on all markets if market is
+// "all") and returns the uuids of the canceled ones. It goes on when a cancel fails,
+// and returns a MultiError of the failures along with the orders canceled. Orders
+// which closed in the meantime (ErrAlreadyClosed) are neither canceled nor failed.
+func (b *Bittrex) CancelAllOrders(market string) (canceled []string, err error) {
+	orders, err := b.GetOpenOrders(market)
+	if err != nil {
+		return
+	}
+	var errs MultiError
+	for _, order := range orders {
+		if err := b.CancelOrder(order.OrderUuid); err != nil {
+			if !errors.Is(err, ErrAlreadyClosed) {
+				errs = append(errs, fmt.Errorf("cancel %s: %w", order.OrderUuid, err))
+			}
+			continue
+		}
+		canceled = append(canceled, order.OrderUuid)
+	}
+	if len(errs) > 0 {
+		return canceled, errs
+	}
+	return canceled, nil
+}
+
+// BuyMarketQuote buys at market on market for about quoteAmount of the base currency
+// (ex: BTC for BTC-LTC), commission included. The quantity is computed from the current
+// ask increased by the slippage buffer (see SetQuoteSlippageBuffer), and floored to the
+// market quantity step. The price can move between the ticker request and the order:
+// if it rises more than the buffer, the order costs more than quoteAmount.
+func (b *Bittrex) BuyMarketQuote(market string, quoteAmount decimal.Decimal) (uuid string, err error) {
+	if !quoteAmount.IsPositive() {
+		return "", fmt.Errorf("quote amount must be positive, got %s", quoteAmount)
+	}
+	ticker, err := b.GetTicker(market)
+	if err != nil {
+		return
+	}
+	if !ticker.Ask.IsPositive() {
+		return "", fmt.Errorf("no ask on %s", market)
+	}
+	one := decimal.New(1, 0)
+	unitCost := ticker.Ask.Mul(one.Add(b.quoteSlippageBuffer)).Mul(one.Add(b.commissionRate))
+	quantity, err := b.RoundQuantityToStep(market, quoteAmount.Div(unitCost))
+	if err != nil {
+		return
+	}
+	if !quantity.IsPositive() {
+		return "", fmt.Errorf("quote amount %s is too small to buy on %s", quoteAmount, market)
+	}
+	return b.BuyMarket(market, quantity)
+}
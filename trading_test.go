@@ -0,0 +1,368 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestBuyMarketMaxSlippage(t *testing.T) {
+	var buys []string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		switch {
+		case strings.Contains(req.URL.Path, "getorderbook"):
+			return http.StatusOK, `{"success":true,"message":"","result":[{"Quantity":1,"Rate":100},{"Quantity":1,"Rate":102}]}`
+		case strings.Contains(req.URL.Path, "buylimit"):
+			buys = append(buys, req.URL.Query().Get("rate"))
+			return http.StatusOK, `{"success":true,"message":"","result":{"uuid":"abc"}}`
+		}
+		return http.StatusNotFound, ""
+	})
+
+	// average fill price of 2 is 101, 100 bps above the best ask
+	if _, err := bt.BuyMarketMaxSlippage("BTC-LTC", decimal.New(2, 0), 50); !errors.Is(err, ErrSlippageTooHigh) {
+		t.Fatalf("expected ErrSlippageTooHigh, got %v", err)
+	}
+	if len(buys) != 0 {
+		t.Fatalf("no order expected, got %v", buys)
+	}
+
+	uuid, err := bt.BuyMarketMaxSlippage("BTC-LTC", decimal.New(2, 0), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uuid != "abc" || len(buys) != 1 || buys[0] != "102" {
+		t.Errorf("expected one buy at 102, got %s %v", uuid, buys)
+	}
+}
+
+func TestCancelOrderAlreadyClosed(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":false,"message":"ORDER_NOT_OPEN","result":null}`
+	})
+	err := bt.CancelOrder("abc")
+	if !errors.Is(err, ErrAlreadyClosed) || !IsAPIError(err) {
+		t.Errorf("expected ErrAlreadyClosed wrapping an API error, got %v", err)
+	}
+	if err.Error() != "order already closed: ORDER_NOT_OPEN" {
+		t.Errorf("unexpected message %q", err)
+	}
+
+	bt.SetBenignMessages([]string{"ORDER_NOT_OPEN"})
+	if err := bt.CancelOrder("abc"); err != nil {
+		t.Errorf("expected no error for a benign message, got %v", err)
+	}
+	bt.SetBenignMessages(nil)
+	bt.SetIdempotentCancel(true)
+	if err := bt.CancelOrder("abc"); err != nil {
+		t.Errorf("expected no error with idempotent cancel, got %v", err)
+	}
+}
+
+func TestMarketOrderEndpoints(t *testing.T) {
+	var requests []string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		query := req.URL.Query()
+		requests = append(requests, req.URL.Path+"?market="+query.Get("market")+"&quantity="+query.Get("quantity"))
+		return http.StatusOK, `{"success":true,"message":"","result":{"uuid":"abc"}}`
+	})
+	if _, err := bt.BuyMarket("BTC-LTC", decimal.New(15, -1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bt.SellMarket("BTC-LTC", decimal.New(15, -1)); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"/api/v1.1/market/buymarket?market=BTC-LTC&quantity=1.5",
+		"/api/v1.1/market/sellmarket?market=BTC-LTC&quantity=1.5",
+	}
+	for i := range expected {
+		if i >= len(requests) || requests[i] != expected[i] {
+			t.Errorf("request %d: expected %s, got %v", i, expected[i], requests)
+		}
+	}
+}
+
+func TestCancelAllOrders(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if strings.Contains(req.URL.Path, "getopenorders") {
+			return http.StatusOK, `{"success":true,"message":"","result":[{"OrderUuid":"a"},{"OrderUuid":"b"},{"OrderUuid":"c"}]}`
+		}
+		switch req.URL.Query().Get("uuid") {
+		case "b":
+			return http.StatusOK, `{"success":false,"message":"APIKEY_INVALID","result":null}`
+		case "c":
+			return http.StatusOK, `{"success":false,"message":"ORDER_NOT_OPEN","result":null}`
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":null}`
+	})
+	canceled, err := bt.CancelAllOrders("all")
+	if len(canceled) != 1 || canceled[0] != "a" {
+		t.Errorf("expected a to be canceled, got %v", canceled)
+	}
+	var errs MultiError
+	if !errors.As(err, &errs) || len(errs) != 1 || !IsAPIError(errs[0]) {
+		t.Errorf("expected the failure of b, got %v", err)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	var paths []string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		paths = append(paths, req.URL.Path)
+		return http.StatusOK, `{"success":true,"message":"","result":{"Bid":1,"Ask":2,"Last":1.5}}`
+	})
+	bt.SetLogger(log.New(ioutil.Discard, "", 0))
+	bt.SetDryRun(true)
+
+	uuid, err := bt.BuyLimit("BTC-LTC", decimal.New(1, 0), decimal.New(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := bt.BuyLimit("BTC-LTC", decimal.New(1, 0), decimal.New(1, 0))
+	if !strings.HasPrefix(uuid, "dryrun-") || uuid != again {
+		t.Errorf("expected a deterministic fake uuid, got %s and %s", uuid, again)
+	}
+	if err := bt.CancelOrder(uuid); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bt.GetTicker("BTC-LTC"); err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 || paths[0] != "/api/v1.1/public/getticker" {
+		t.Errorf("expected only the ticker request to be sent, got %v", paths)
+	}
+}
+
+func TestBuyMarketQuote(t *testing.T) {
+	var quantity string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		switch {
+		case strings.Contains(req.URL.Path, "getmarkets"):
+			return http.StatusOK, `{"success":true,"message":"","result":[{"MarketName":"BTC-LTC","MinTradeSize":0.01}]}`
+		case strings.Contains(req.URL.Path, "getticker"):
+			return http.StatusOK, `{"success":true,"message":"","result":{"Bid":0.009,"Ask":0.01,"Last":0.01}}`
+		}
+		quantity = req.URL.Query().Get("quantity")
+		return http.StatusOK, `{"success":true,"message":"","result":{"uuid":"abc"}}`
+	})
+	bt.SetCommissionRate(decimal.Zero)
+	bt.SetQuoteSlippageBuffer(decimal.RequireFromString("0.25"))
+
+	// 1 BTC at 0.0125 (the ask plus 25%) buys 80 LTC
+	if _, err := bt.BuyMarketQuote("BTC-LTC", decimal.New(1, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if quantity != "80" {
+		t.Errorf("expected a quantity of 80, got %s", quantity)
+	}
+}
+
+func TestSellLimitOrder(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":true,"message":"","result":{"uuid":"abc"}}`
+	})
+	order, err := bt.SellLimitOrder("BTC-LTC", decimal.RequireFromString("1.123456789"), decimal.RequireFromString("0.01"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Uuid != "abc" || order.Side != SELL || order.Quantity.String() != "1.12345678" || order.Rate.String() != "0.01" || string(order.Raw) != `{"uuid":"abc"}` {
+		t.Errorf("unexpected order result %+v", order)
+	}
+}
+
+func TestWaitForOrder(t *testing.T) {
+	polls := 0
+	remaining := "0"
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		polls++
+		if polls < 3 {
+			return http.StatusOK, `{"success":true,"message":"","result":{"OrderUuid":"abc","Quantity":2,"QuantityRemaining":2,"IsOpen":true}}`
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":{"OrderUuid":"abc","Quantity":2,"QuantityRemaining":` + remaining + `,"IsOpen":false}}`
+	})
+	order, err := bt.WaitForOrder(context.Background(), "abc", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if polls != 3 || !order.IsFilled() || order.IsCanceled() {
+		t.Errorf("expected a filled order after 3 polls, got %+v after %d", order, polls)
+	}
+
+	polls, remaining = 0, "1.5"
+	order, err = bt.WaitForOrder(context.Background(), "abc", time.Millisecond)
+	if err != nil || !order.IsCanceled() || order.IsFilled() {
+		t.Errorf("expected a canceled order, got %+v, %v", order, err)
+	}
+	polls = 0
+	if _, err = bt.WaitForFill(context.Background(), "abc", time.Millisecond); err != ErrOrderCanceled {
+		t.Errorf("WaitForFill: expected ErrOrderCanceled, got %v", err)
+	}
+
+	polls = -1000
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if order, err = bt.WaitForOrder(ctx, "abc", time.Millisecond); err != context.DeadlineExceeded || !order.IsOpen {
+		t.Errorf("expected context.DeadlineExceeded with the open order, got %+v, %v", order, err)
+	}
+	if _, err = bt.WaitForOrder(context.Background(), "abc", 0); err != ErrInvalidPollInterval {
+		t.Errorf("expected ErrInvalidPollInterval, got %v", err)
+	}
+}
+
+func TestValidateMinTradeSize(t *testing.T) {
+	var getMarkets, orders int
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if strings.HasSuffix(req.URL.Path, "/public/getmarkets") {
+			getMarkets++
+			return http.StatusOK, `{"success":true,"message":"","result":[{"MarketName":"BTC-LTC","BaseCurrency":"BTC","MinTradeSize":0.01}]}`
+		}
+		orders++
+		return http.StatusOK, `{"success":true,"message":"","result":{"uuid":"abc"}}`
+	})
+	if _, err := bt.BuyLimit("BTC-LTC", decimal.RequireFromString("0.001"), decimal.New(1, 0)); err != nil || getMarkets != 0 {
+		t.Errorf("disabled: expected the order to be sent without fetching markets, got %v", err)
+	}
+
+	bt.SetValidateMinTradeSize(true)
+	orders = 0
+	if _, err := bt.SellLimit("BTC-LTC", decimal.RequireFromString("0.001"), decimal.New(1, 0)); !errors.Is(err, ErrMinTradeRequirement) {
+		t.Errorf("SellLimit: expected ErrMinTradeRequirement, got %v", err)
+	}
+	if _, err := bt.BuyMarket("BTC-LTC", decimal.RequireFromString("0.005")); !errors.Is(err, ErrMinTradeRequirement) {
+		t.Errorf("BuyMarket: expected ErrMinTradeRequirement, got %v", err)
+	}
+	if _, err := bt.BuyLimit("BTC-LTC", decimal.RequireFromString("0.01"), decimal.New(1, 0)); err != nil {
+		t.Errorf("BuyLimit: %v", err)
+	}
+	if orders != 1 || getMarkets != 1 {
+		t.Errorf("expected 1 order and markets fetched once, got %d orders and %d fetches", orders, getMarkets)
+	}
+}
+
+func TestSelfTradePrevention(t *testing.T) {
+	var orders []string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if strings.HasSuffix(req.URL.Path, "/market/getopenorders") {
+			if req.URL.Query().Get("market") != "BTC-LTC" {
+				t.Errorf("unexpected open orders request %s", req.URL)
+			}
+			return http.StatusOK, `{"success":true,"message":"","result":[
+				{"OrderUuid":"sell","Exchange":"BTC-LTC","OrderType":"LIMIT_SELL","Limit":0.012},
+				{"OrderUuid":"buy","Exchange":"BTC-LTC","OrderType":"LIMIT_BUY","Limit":0.01},
+				{"OrderUuid":"market","Exchange":"BTC-LTC","OrderType":"MARKET_SELL","Limit":null}]}`
+		}
+		orders = append(orders, req.URL.Query().Get("rate"))
+		return http.StatusOK, `{"success":true,"message":"","result":{"uuid":"abc"}}`
+	})
+	one := decimal.New(1, 0)
+	if _, err := bt.BuyLimit("BTC-LTC", one, decimal.RequireFromString("0.013")); err != nil || len(orders) != 1 {
+		t.Errorf("disabled: expected the order to be sent, got %v", err)
+	}
+
+	bt.SetSelfTradePrevention(true)
+	orders = nil
+	if _, err := bt.BuyLimit("BTC-LTC", one, decimal.RequireFromString("0.012")); !errors.Is(err, ErrWouldSelfTrade) || !strings.Contains(err.Error(), "sell") {
+		t.Errorf("buy crossing the resting sell: expected ErrWouldSelfTrade, got %v", err)
+	}
+	if _, err := bt.SellLimit("BTC-LTC", one, decimal.RequireFromString("0.009")); !errors.Is(err, ErrWouldSelfTrade) || !strings.Contains(err.Error(), "buy") {
+		t.Errorf("sell crossing the resting buy: expected ErrWouldSelfTrade, got %v", err)
+	}
+	// inside the spread, neither side crosses
+	if _, err := bt.BuyLimit("BTC-LTC", one, decimal.RequireFromString("0.011")); err != nil {
+		t.Errorf("buy without cross: %v", err)
+	}
+	if _, err := bt.SellLimit("BTC-LTC", one, decimal.RequireFromString("0.011")); err != nil {
+		t.Errorf("sell without cross: %v", err)
+	}
+	if len(orders) != 2 {
+		t.Errorf("expected only the 2 orders without cross sent, got %v", orders)
+	}
+}
+
+func TestPassiveLimitPrice(t *testing.T) {
+	var book string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if strings.Contains(req.URL.Path, "getmarkets") {
+			return http.StatusOK, `{"success":true,"message":"","result":[{"MarketName":"BTC-LTC","Precision":3}]}`
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":` + book + `}`
+	})
+	wide := `{"buy":[{"Quantity":1,"Rate":0.010}],"sell":[{"Quantity":1,"Rate":0.015}]}`
+	tests := []struct {
+		book  string
+		side  OrderSide
+		ticks int
+		price string
+	}{
+		{wide, BUY, 2, "0.012"},
+		{wide, SELL, 2, "0.013"},
+		// at the best price
+		{wide, BUY, 0, "0.01"},
+		{wide, SELL, 0, "0.015"},
+		// capped one tick away from the opposite best price
+		{wide, BUY, 10, "0.014"},
+		{wide, SELL, 10, "0.011"},
+		// a one tick spread leaves no room to improve the best price
+		{`{"buy":[{"Quantity":1,"Rate":0.010}],"sell":[{"Quantity":1,"Rate":0.011}]}`, BUY, 1, "0.01"},
+		{`{"buy":[{"Quantity":1,"Rate":0.010}],"sell":[{"Quantity":1,"Rate":0.011}]}`, SELL, 1, "0.011"},
+	}
+	for _, test := range tests {
+		book = test.book
+		price, err := bt.PassiveLimitPrice("BTC-LTC", test.side, test.ticks)
+		if err != nil {
+			t.Errorf("%s %d ticks: %v", test.side, test.ticks, err)
+			continue
+		}
+		if !price.Equal(decimal.RequireFromString(test.price)) {
+			t.Errorf("%s %d ticks on %s: expected %s, got %s", test.side, test.ticks, test.book, test.price, price)
+		}
+	}
+
+	book = `{"buy":[{"Quantity":1,"Rate":0.010}],"sell":[]}`
+	if _, err := bt.PassiveLimitPrice("BTC-LTC", BUY, 1); err == nil {
+		t.Error("expected an error for a one-sided book")
+	}
+}
+
+func TestBuyingPower(t *testing.T) {
+	ask := "0.01"
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		switch {
+		case strings.Contains(req.URL.Path, "getmarkets"):
+			return http.StatusOK, `{"success":true,"message":"","result":[{"MarketName":"BTC-LTC","BaseCurrency":"BTC","MarketCurrency":"LTC"}]}`
+		case strings.Contains(req.URL.Path, "getbalance"):
+			if currency := req.URL.Query().Get("currency"); currency != "BTC" {
+				t.Errorf("expected the balance of BTC, got %s", currency)
+			}
+			return http.StatusOK, `{"success":true,"message":"","result":{"Currency":"BTC","Balance":2,"Available":1}}`
+		}
+		return http.StatusOK, `{"success":true,"message":"","result":{"Bid":0.009,"Ask":` + ask + `,"Last":0.01}}`
+	})
+
+	// 1 BTC at 0.01 plus 0.25% of commission buys 99.750623441396508... LTC, floored to the step
+	quantity, err := bt.BuyingPower("BTC-LTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quantity.String() != "99.75062344" {
+		t.Errorf("expected 99.75062344, got %s", quantity)
+	}
+
+	bt.SetCommissionRate(decimal.Zero)
+	if quantity, err = bt.BuyingPower("BTC-LTC"); err != nil || quantity.String() != "100" {
+		t.Errorf("expected 100 without commission, got %s, %v", quantity, err)
+	}
+
+	ask = "0"
+	if _, err = bt.BuyingPower("BTC-LTC"); err == nil {
+		t.Error("expected an error without ask")
+	}
+}
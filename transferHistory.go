@@ -0,0 +1,90 @@
+package bittrex
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// V3_MAX_PAGE_SIZE is the largest page of the paginated v3 history endpoints
+const V3_MAX_PAGE_SIZE = 200
+
+// Transfer is a closed deposit or withdrawal of the v3 API
+type Transfer struct {
+	Id               string            `json:"id"`
+	Direction        TransferDirection `json:"-"`
+	Currency         string            `json:"currencySymbol"`
+	Quantity         decimal.Decimal   `json:"quantity"`
+	CryptoAddress    string            `json:"cryptoAddress"`
+	CryptoAddressTag string            `json:"cryptoAddressTag"`
+	TxCost           decimal.Decimal   `json:"txCost"` // withdrawals only
+	TxId             string            `json:"txId"`
+	Status           string            `json:"status"`
+	CreatedAt        time.Time         `json:"createdAt"` // withdrawals only
+	UpdatedAt        time.Time         `json:"updatedAt"` // deposits only
+	CompletedAt      time.Time         `json:"completedAt"`
+}
+
+// Time returns the time the transfer completed, or was last updated (deposit) or
+// created (withdrawal) if it did not complete.
+func (t Transfer) Time() time.Time {
+	switch {
+	case !t.CompletedAt.IsZero():
+		return t.CompletedAt
+	case !t.UpdatedAt.IsZero():
+		return t.UpdatedAt
+	}
+	return t.CreatedAt
+}
+
+// GetDepositHistoryAll returns your whole closed deposit history of currency (or of all
+// currencies if currency is "all"), sorted by time (oldest first). Unlike GetDepositHistory,
+// which is capped to a single page, it follows the pages of the v3 API, V3_MAX_PAGE_SIZE
+// deposits per request.
+func (b *Bittrex) GetDepositHistoryAll(currency string) ([]Transfer, error) {
+	return b.getTransferHistory("deposits/closed", TRANSFER_DEPOSIT, currency)
+}
+
+// GetWithdrawalHistoryAll is like GetDepositHistoryAll for your closed withdrawals
+func (b *Bittrex) GetWithdrawalHistoryAll(currency string) ([]Transfer, error) {
+	return b.getTransferHistory("withdrawals/closed", TRANSFER_WITHDRAWAL, currency)
+}
+
+// getTransferHistory fetches every page of the v3 transfer history resource. Each page
+// starts after the last transfer of the previous one, and a short page is the last one.
+func (b *Bittrex) getTransferHistory(resource string, direction TransferDirection, currency string) (transfers []Transfer, err error) {
+	if currency == "" {
+		return nil, ErrEmptyCurrency
+	}
+	params := url.Values{"pageSize": {strconv.Itoa(V3_MAX_PAGE_SIZE)}}
+	if currency != "all" {
+		params.Set("currencySymbol", strings.ToUpper(currency))
+	}
+	for {
+		r, err := b.client.doV3("GET", API_V3_BASE+resource+"?"+params.Encode(), "")
+		if err != nil {
+			return nil, decodeV3Error(r, err)
+		}
+		var page []Transfer
+		if err = b.decoder.Unmarshal(r, &page); err != nil {
+			return nil, fmt.Errorf("could not unmarshal %s: %v", resource, err)
+		}
+		for _, t := range page {
+			t.Direction = direction
+			transfers = append(transfers, t)
+		}
+		if len(page) < V3_MAX_PAGE_SIZE {
+			break
+		}
+		params.Set("nextPageToken", page[len(page)-1].Id)
+	}
+	sort.SliceStable(transfers, func(i, j int) bool {
+		return transfers[i].Time().Before(transfers[j].Time())
+	})
+	return transfers, nil
+}
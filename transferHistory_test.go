@@ -0,0 +1,63 @@
+package bittrex
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetDepositHistoryAll(t *testing.T) {
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	// deposits are returned most recent first, the oldest one alone on the last page
+	deposit := func(i int) string {
+		return fmt.Sprintf(`{"id":"d%d","currencySymbol":"BTC","quantity":"0.1","status":"COMPLETED","completedAt":%q}`, i, start.Add(time.Duration(i)*time.Hour).Format(time.RFC3339))
+	}
+	var tokens []string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		q := req.URL.Query()
+		if req.URL.Path != "/v3/deposits/closed" || q.Get("currencySymbol") != "BTC" || q.Get("pageSize") != "200" {
+			t.Errorf("unexpected request %s", req.URL)
+		}
+		if req.Header.Get("Api-Signature") == "" {
+			t.Error("request not signed")
+		}
+		tokens = append(tokens, q.Get("nextPageToken"))
+		var page []string
+		switch q.Get("nextPageToken") {
+		case "":
+			for i := V3_MAX_PAGE_SIZE; i > 0; i-- {
+				page = append(page, deposit(i))
+			}
+		case "d1":
+			page = append(page, deposit(0))
+		}
+		return http.StatusOK, "[" + strings.Join(page, ",") + "]"
+	})
+	deposits, err := bt.GetDepositHistoryAll("btc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(tokens, ",") != ",d1" {
+		t.Errorf("expected a second page after d1, got page tokens %q", tokens)
+	}
+	if len(deposits) != V3_MAX_PAGE_SIZE+1 {
+		t.Fatalf("expected %d deposits, got %d", V3_MAX_PAGE_SIZE+1, len(deposits))
+	}
+	if deposits[0].Id != "d0" || deposits[len(deposits)-1].Id != "d200" || deposits[0].Direction != TRANSFER_DEPOSIT {
+		t.Errorf("expected deposits sorted from d0 to d200, got %+v ... %+v", deposits[0], deposits[len(deposits)-1])
+	}
+}
+
+func TestGetWithdrawalHistoryAllError(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if _, ok := req.URL.Query()["currencySymbol"]; ok {
+			t.Error("expected no currencySymbol for all currencies")
+		}
+		return http.StatusUnauthorized, `{"code":"INVALID_SIGNATURE"}`
+	})
+	if _, err := bt.GetWithdrawalHistoryAll("all"); !IsAPIError(err) || err.Error() != "INVALID_SIGNATURE" {
+		t.Errorf("expected INVALID_SIGNATURE, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package bittrex
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// BittrexV3 is a client of Bittrex API v3, returned by NewV3 and its variants. Its requests
+// are signed with the Api-Key, Api-Timestamp, Api-Content-Hash and Api-Signature headers.
+// It is a migration path off the v1.1 API, which Bittrex deprecates; the v1.1 client
+// returned by New keeps working alongside.
+// Markets are designated by their v3 symbol, the reverse of the v1.1 name (ex: LTC-BTC
+// for BTC-LTC).
+type BittrexV3 struct {
+	client *client
+}
+
+// NewV3 returns a Bittrex API v3 client using the given API credentials.
+// The credentials may be empty to only call the public endpoints.
+func NewV3(apiKey, apiSecret string) *BittrexV3 {
+	return &BittrexV3{client: NewClient(apiKey, apiSecret)}
+}
+
+// NewV3WithCustomHttpClient returns a Bittrex API v3 client using a custom http client
+func NewV3WithCustomHttpClient(apiKey, apiSecret string, httpClient *http.Client) *BittrexV3 {
+	return &BittrexV3{client: NewClientWithCustomHttpConfig(apiKey, apiSecret, httpClient)}
+}
+
+// V3Market is a market of the v3 API
+type V3Market struct {
+	Symbol              string          `json:"symbol"`
+	BaseCurrencySymbol  string          `json:"baseCurrencySymbol"`  // ex: LTC for LTC-BTC
+	QuoteCurrencySymbol string          `json:"quoteCurrencySymbol"` // ex: BTC for LTC-BTC
+	MinTradeSize        decimal.Decimal `json:"minTradeSize"`
+	Precision           int             `json:"precision"`
+	Status              string          `json:"status"` // ONLINE or OFFLINE
+	CreatedAt           time.Time       `json:"createdAt"`
+	Notice              string          `json:"notice"`
+}
+
+// V3Ticker is the ticker of a market of the v3 API
+type V3Ticker struct {
+	Symbol        string          `json:"symbol"`
+	LastTradeRate decimal.Decimal `json:"lastTradeRate"`
+	BidRate       decimal.Decimal `json:"bidRate"`
+	AskRate       decimal.Decimal `json:"askRate"`
+}
+
+// V3Balance is the balance of a currency of the v3 API
+type V3Balance struct {
+	CurrencySymbol string          `json:"currencySymbol"`
+	Total          decimal.Decimal `json:"total"`
+	Available      decimal.Decimal `json:"available"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+}
+
+// V3Order is an order of the v3 API.
+// As for Order, optional fields are pointers, nil when absent.
+type V3Order struct {
+	Id            string           `json:"id"`
+	MarketSymbol  string           `json:"marketSymbol"`
+	Direction     string           `json:"direction"` // BUY or SELL
+	Type          string           `json:"type"`      // LIMIT, MARKET, CEILING_LIMIT or CEILING_MARKET
+	Quantity      decimal.Decimal  `json:"quantity"`
+	Limit         *decimal.Decimal `json:"limit"`
+	Ceiling       *decimal.Decimal `json:"ceiling"`
+	TimeInForce   string           `json:"timeInForce"`
+	ClientOrderId string           `json:"clientOrderId"`
+	FillQuantity  decimal.Decimal  `json:"fillQuantity"`
+	Commission    decimal.Decimal  `json:"commission"`
+	Proceeds      decimal.Decimal  `json:"proceeds"`
+	Status        string           `json:"status"` // OPEN or CLOSED
+	CreatedAt     time.Time        `json:"createdAt"`
+	UpdatedAt     *time.Time       `json:"updatedAt"`
+	ClosedAt      *time.Time       `json:"closedAt"`
+}
+
+// get calls the v3 resource and decodes its response in v. Authenticated requests are
+// signed, and a v3 error code is returned as an *APIError.
+func (b *BittrexV3) get(resource string, authNeeded bool, v interface{}) error {
+	var (
+		r   []byte
+		err error
+	)
+	if authNeeded {
+		r, err = b.client.doV3("GET", API_V3_BASE+resource, "")
+	} else {
+		r, err = b.client.do("GET", API_V3_BASE+resource, "", false)
+	}
+	if err != nil {
+		return decodeV3Error(r, err)
+	}
+	return json.Unmarshal(r, v)
+}
+
+// GetMarkets returns the markets of Bittrex
+func (b *BittrexV3) GetMarkets() (markets []V3Market, err error) {
+	err = b.get("markets", false, &markets)
+	return
+}
+
+// GetTickers returns the tickers of all the markets
+func (b *BittrexV3) GetTickers() (tickers []V3Ticker, err error) {
+	err = b.get("markets/tickers", false, &tickers)
+	return
+}
+
+// GetTicker returns the ticker of market, a v3 symbol (ex: LTC-BTC)
+func (b *BittrexV3) GetTicker(market string) (ticker V3Ticker, err error) {
+	if market == "" {
+		return ticker, ErrEmptyMarket
+	}
+	err = b.get("markets/"+url.PathEscape(strings.ToUpper(market))+"/ticker", false, &ticker)
+	return
+}
+
+// GetBalances returns the balances of your account
+func (b *BittrexV3) GetBalances() (balances []V3Balance, err error) {
+	err = b.get("balances", true, &balances)
+	return
+}
+
+// GetOpenOrders returns your open orders of market, a v3 symbol (ex: LTC-BTC),
+// or of all markets if market is "all".
+func (b *BittrexV3) GetOpenOrders(market string) (orders []V3Order, err error) {
+	resource := "orders/open"
+	if market != "all" {
+		resource += "?" + url.Values{"marketSymbol": {strings.ToUpper(market)}}.Encode()
+	}
+	err = b.get(resource, true, &orders)
+	return
+}
+
+// GetOrder returns your order of id
+func (b *BittrexV3) GetOrder(id string) (order V3Order, err error) {
+	err = b.get("orders/"+url.PathEscape(id), true, &order)
+	return
+}
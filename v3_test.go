@@ -0,0 +1,106 @@
+package bittrex
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newTestBittrexV3(handler func(req *http.Request) (status int, body string)) *BittrexV3 {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		status, body := handler(req)
+		return &http.Response{
+			Status:     http.StatusText(status),
+			StatusCode: status,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	return NewV3WithCustomHttpClient("key", "secret", &http.Client{Transport: transport})
+}
+
+func TestBittrexV3Public(t *testing.T) {
+	bt := newTestBittrexV3(func(req *http.Request) (int, string) {
+		if req.Header.Get("Api-Signature") != "" {
+			t.Errorf("public request %s signed", req.URL)
+		}
+		switch req.URL.Path {
+		case "/v3/markets":
+			return http.StatusOK, `[{"symbol":"LTC-BTC","baseCurrencySymbol":"LTC","quoteCurrencySymbol":"BTC","minTradeSize":"0.01","precision":8,"status":"ONLINE"}]`
+		case "/v3/markets/LTC-BTC/ticker":
+			return http.StatusOK, `{"symbol":"LTC-BTC","lastTradeRate":"0.0118","bidRate":"0.0117","askRate":"0.0119"}`
+		}
+		return http.StatusNotFound, `{"code":"MARKET_DOES_NOT_EXIST"}`
+	})
+	markets, err := bt.GetMarkets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(markets) != 1 || markets[0].QuoteCurrencySymbol != "BTC" || markets[0].MinTradeSize.String() != "0.01" {
+		t.Errorf("unexpected markets %+v", markets)
+	}
+	ticker, err := bt.GetTicker("ltc-btc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticker.BidRate.String() != "0.0117" {
+		t.Errorf("unexpected ticker %+v", ticker)
+	}
+	if _, err := bt.GetTicker("XXX-BTC"); !errors.Is(err, ErrInvalidMarket) {
+		t.Errorf("expected ErrInvalidMarket, got %v", err)
+	}
+}
+
+func TestBittrexV3Signed(t *testing.T) {
+	var query string
+	bt := newTestBittrexV3(func(req *http.Request) (int, string) {
+		hash := sha512.Sum512(nil)
+		contentHash := hex.EncodeToString(hash[:])
+		mac := hmac.New(sha512.New, []byte("secret"))
+		mac.Write([]byte(req.Header.Get("Api-Timestamp") + req.URL.String() + "GET" + contentHash))
+		if req.Header.Get("Api-Key") != "key" || req.Header.Get("Api-Content-Hash") != contentHash ||
+			req.Header.Get("Api-Signature") != hex.EncodeToString(mac.Sum(nil)) {
+			t.Errorf("wrong signature for %s", req.URL)
+		}
+		query = req.URL.RawQuery
+		switch req.URL.Path {
+		case "/v3/balances":
+			return http.StatusOK, `[{"currencySymbol":"BTC","total":"1.5","available":"1"}]`
+		case "/v3/orders/open":
+			return http.StatusOK, `[{"id":"abc","marketSymbol":"LTC-BTC","direction":"BUY","type":"LIMIT","quantity":"1","limit":"0.01","status":"OPEN","closedAt":null}]`
+		}
+		return http.StatusOK, `{"id":"abc","status":"CLOSED","fillQuantity":"1","closedAt":"2020-07-13T10:00:00Z"}`
+	})
+	balances, err := bt.GetBalances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != 1 || balances[0].Total.String() != "1.5" {
+		t.Errorf("unexpected balances %+v", balances)
+	}
+	orders, err := bt.GetOpenOrders("ltc-btc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "marketSymbol=LTC-BTC" || len(orders) != 1 || orders[0].Limit.String() != "0.01" || orders[0].ClosedAt != nil {
+		t.Errorf("unexpected orders %+v for %s", orders, query)
+	}
+	order, err := bt.GetOrder("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != "CLOSED" || order.ClosedAt == nil {
+		t.Errorf("unexpected order %+v", order)
+	}
+
+	bt = NewV3("", "")
+	if _, err := bt.GetBalances(); err != ErrAuthNotConfigured {
+		t.Errorf("expected ErrAuthNotConfigured, got %v", err)
+	}
+}
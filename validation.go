@@ -0,0 +1,90 @@
+package bittrex
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// DECIMAL_PRECISION is the number of decimal places accepted by Bittrex for quantities and rates
+const DECIMAL_PRECISION = 8
+
+// DEFAULT_STEP is the smallest quantity or rate increment accepted by Bittrex
+var DEFAULT_STEP = decimal.New(1, -DECIMAL_PRECISION)
+
+// MIN_ORDER_VALUE is the minimum order value (quantity * rate), by base currency,
+// under which Bittrex rejects orders as dust trades.
+var MIN_ORDER_VALUE = map[string]decimal.Decimal{
+	"BTC": decimal.New(50000, -DECIMAL_PRECISION), // 50K satoshis
+}
+
+// ValidateOrder checks an order against the market constraints (minimum trade size,
+// minimum order value and precision) and returns every violation found.
+// The returned slice is empty if the order is valid. The minimum trade size and order
+// value violations match ErrMinTradeRequirement and ErrDustTrade with errors.Is.
+func (b *Bittrex) ValidateOrder(market string, quantity, rate decimal.Decimal) (errs []error) {
+	m, err := b.getMarket(market)
+	if err != nil {
+		return []error{err}
+	}
+	if err := m.checkMinTradeSize(quantity); err != nil {
+		errs = append(errs, err)
+	}
+	if minValue, ok := MIN_ORDER_VALUE[m.BaseCurrency]; ok {
+		if value := quantity.Mul(rate); value.LessThan(minValue) {
+			errs = append(errs, fmt.Errorf("%w: order value %s %s is below the minimum %s %s of %s", ErrDustTrade, value, m.BaseCurrency, minValue, m.BaseCurrency, m.MarketName))
+		}
+	}
+	if step := m.QuantityStep(); !quantity.Mod(step).IsZero() {
+		errs = append(errs, fmt.Errorf("quantity %s is not a multiple of the step %s of %s", quantity, step, m.MarketName))
+	}
+	if tick := m.PriceTick(); !rate.Mod(tick).IsZero() {
+		errs = append(errs, fmt.Errorf("rate %s is not a multiple of the tick %s of %s", rate, tick, m.MarketName))
+	}
+	return
+}
+
+// RoundQuantityToStep floors quantity to the quantity step of market.
+// Flooring (not rounding) is used so the rounded quantity never exceeds the available funds.
+func (b *Bittrex) RoundQuantityToStep(market string, quantity decimal.Decimal) (decimal.Decimal, error) {
+	m, err := b.getMarket(market)
+	if err != nil {
+		return quantity, err
+	}
+	step := m.QuantityStep()
+	return quantity.Div(step).Floor().Mul(step), nil
+}
+
+// checkMinTradeSize returns an error wrapping ErrMinTradeRequirement if quantity is below
+// the minimum trade size of market.
+func (b *Bittrex) checkMinTradeSize(market string, quantity decimal.Decimal) error {
+	m, err := b.getMarket(market)
+	if err != nil {
+		return err
+	}
+	return m.checkMinTradeSize(quantity)
+}
+
+// checkMinTradeSize returns an error wrapping ErrMinTradeRequirement if quantity is below
+// the minimum trade size of m. It is the check of both ValidateOrder and the order placing
+// methods (see SetValidateMinTradeSize).
+func (m Market) checkMinTradeSize(quantity decimal.Decimal) error {
+	if quantity.LessThan(m.MinTradeSize) {
+		return fmt.Errorf("%w: quantity %s is below the minimum trade size %s of %s", ErrMinTradeRequirement, quantity, m.MinTradeSize, m.MarketName)
+	}
+	return nil
+}
+
+// formatOrderValue formats the order value v (named name in messages) with the precision
+// accepted by Bittrex. The truncation of extra decimals is logged as a warning, or
+// returned as an error in strict precision mode.
+func (b *Bittrex) formatOrderValue(name string, v decimal.Decimal) (string, error) {
+	t := v.Truncate(DECIMAL_PRECISION)
+	if !t.Equal(v) {
+		if b.strictPrecision {
+			return "", fmt.Errorf("%w: %s %s has more than %d decimals", ErrPrecisionTruncated, name, v, DECIMAL_PRECISION)
+		}
+		b.client.logf("warning: %s %s truncated to %s", name, v, t)
+	}
+	return t.String(), nil
+}
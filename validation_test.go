@@ -0,0 +1,88 @@
+package bittrex
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestValidateOrder(t *testing.T) {
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"success":true,"message":"","result":[
+			{"MarketName":"BTC-LTC","BaseCurrency":"BTC","MarketCurrency":"LTC","MinTradeSize":0.01,"Precision":4}]}`
+	})
+	d := decimal.RequireFromString
+
+	if errs := bt.ValidateOrder("BTC-LTC", d("1"), d("0.0123")); len(errs) != 0 {
+		t.Errorf("expected a valid order, got %v", errs)
+	}
+
+	errs := bt.ValidateOrder("BTC-LTC", d("0.005"), d("0.00123"))
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 violations, got %v", errs)
+	}
+	if !errors.Is(errs[0], ErrMinTradeRequirement) {
+		t.Errorf("expected ErrMinTradeRequirement, got %v", errs[0])
+	}
+	if !errors.Is(errs[1], ErrDustTrade) {
+		t.Errorf("expected ErrDustTrade, got %v", errs[1])
+	}
+	if errs[2].Error() != "rate 0.00123 is not a multiple of the tick 0.0001 of BTC-LTC" {
+		t.Errorf("unexpected tick violation %v", errs[2])
+	}
+
+	if errs := bt.ValidateOrder("BTC-LTC", d("1.000000001"), d("0.0123")); len(errs) != 1 {
+		t.Errorf("expected a step violation, got %v", errs)
+	}
+	if errs := bt.ValidateOrder("BTC-XXX", d("1"), d("1")); len(errs) != 1 {
+		t.Errorf("expected an unknown market error, got %v", errs)
+	}
+}
+
+func TestRoundQuantityToStep(t *testing.T) {
+	var quantities []string
+	bt := newTestBittrex(func(req *http.Request) (int, string) {
+		if strings.Contains(req.URL.Path, "getmarkets") {
+			return http.StatusOK, `{"success":true,"message":"","result":[{"MarketName":"BTC-LTC","BaseCurrency":"BTC","MarketCurrency":"LTC"}]}`
+		}
+		quantities = append(quantities, req.URL.Query().Get("quantity"))
+		return http.StatusOK, `{"success":true,"message":"","result":{"uuid":"abc"}}`
+	})
+	d := decimal.RequireFromString
+
+	// floored, never rounded up, to the step of 0.00000001
+	for quantity, rounded := range map[string]string{"1.123456789": "1.12345678", "2": "2", "0.000000009": "0"} {
+		q, err := bt.RoundQuantityToStep("BTC-LTC", d(quantity))
+		if err != nil || q.String() != rounded {
+			t.Errorf("%s: expected %s, got %s, %v", quantity, rounded, q, err)
+		}
+	}
+	if _, err := bt.RoundQuantityToStep("BTC-XXX", d("1")); err == nil {
+		t.Error("expected an error for an unknown market")
+	}
+
+	// the extra decimal is refused unless the quantity is floored
+	bt.SetStrictPrecision(true)
+	if _, err := bt.BuyLimit("BTC-LTC", d("1.123456789"), d("0.01")); !errors.Is(err, ErrPrecisionTruncated) {
+		t.Errorf("expected ErrPrecisionTruncated without rounding, got %v", err)
+	}
+	bt.SetRoundQuantity(true)
+	orders := map[string]func() (string, error){
+		"BuyLimit":   func() (string, error) { return bt.BuyLimit("BTC-LTC", d("1.123456789"), d("0.01")) },
+		"SellLimit":  func() (string, error) { return bt.SellLimit("BTC-LTC", d("1.123456789"), d("0.01")) },
+		"BuyMarket":  func() (string, error) { return bt.BuyMarket("BTC-LTC", d("1.123456789")) },
+		"SellMarket": func() (string, error) { return bt.SellMarket("BTC-LTC", d("1.123456789")) },
+	}
+	for name, order := range orders {
+		quantities = nil
+		if _, err := order(); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if len(quantities) != 1 || quantities[0] != "1.12345678" {
+			t.Errorf("%s: expected the quantity 1.12345678 sent, got %v", name, quantities)
+		}
+	}
+}
@@ -1,13 +1,37 @@
 package bittrex
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/thebotguys/signalr"
 )
 
+// WS_RECONNECT_DELAY is the time waited before reconnecting a managed stream
+const WS_RECONNECT_DELAY = time.Second
+
+// MIN_HEARTBEAT_TIMEOUT is the shortest heartbeat timeout of the streams (see SetHeartbeatTimeout)
+const MIN_HEARTBEAT_TIMEOUT = time.Second
+
+// WS_BUFFER_SIZE is the capacity of the channels of the streams
+const WS_BUFFER_SIZE = 256
+
+var (
+	// ErrStreamStale is returned when no message is received from the stream within the heartbeat timeout
+	ErrStreamStale = errors.New("stream stale: no message received within heartbeat timeout")
+	// ErrStreamDisconnected is reported when the stream connection drops
+	ErrStreamDisconnected = errors.New("stream disconnected")
+	// ErrStreamAuthRejected is returned when Bittrex rejects the authentication of a stream
+	ErrStreamAuthRejected = errors.New("stream authentication rejected")
+	// ErrStreamOverflow is reported when messages are dropped because the consumer of the stream is too slow
+	ErrStreamOverflow = errors.New("stream overflow: messages dropped, consumer too slow")
+)
+
 type OrderUpdate struct {
 	Orderb
 	Type int
@@ -30,6 +54,7 @@ type ExchangeState struct {
 }
 
 // doAsyncTimeout runs f in a different goroutine
+//
 //	if f returns before timeout elapses, doAsyncTimeout returns the result of f().
 //	otherwise it returns "operation timeout" error, and calls tmFunc after f returns.
 func doAsyncTimeout(f func() error, tmFunc func(error), timeout time.Duration) error {
@@ -59,6 +84,17 @@ func sendStateAsync(dataCh chan<- ExchangeState, st ExchangeState) {
 	}
 }
 
+// connectHub connects client to hub (ex: WS_HUB), giving up after timeout
+func connectHub(client *signalr.Client, hub string, timeout time.Duration) error {
+	return doAsyncTimeout(func() error {
+		return client.Connect("https", WS_BASE, []string{hub})
+	}, func(err error) {
+		if err == nil {
+			client.Close()
+		}
+	}, timeout)
+}
+
 func subForMarket(client *signalr.Client, market string) (json.RawMessage, error) {
 	_, err := client.CallHub(WS_HUB, "SubscribeToExchangeDeltas", market)
 	if err != nil {
@@ -80,25 +116,35 @@ func parseStates(messages []json.RawMessage, dataCh chan<- ExchangeState, market
 	}
 }
 
+// SetHeartbeatTimeout sets the time after which a stream without any incoming
+// message is considered stale. 0 (the default), or a negative timeout, disables the
+// check. A timeout below MIN_HEARTBEAT_TIMEOUT is raised to it.
+func (b *Bittrex) SetHeartbeatTimeout(timeout time.Duration) {
+	switch {
+	case timeout <= 0:
+		timeout = 0
+	case timeout < MIN_HEARTBEAT_TIMEOUT:
+		timeout = MIN_HEARTBEAT_TIMEOUT
+	}
+	b.heartbeatTimeout = timeout
+}
+
 // SubscribeExchangeUpdate subscribes for updates of the market.
 // Updates will be sent to dataCh.
 // To stop subscription, send to, or close 'stop'.
+// If a heartbeat timeout is set and no message is received in time, ErrStreamStale is returned.
 func (b *Bittrex) SubscribeExchangeUpdate(market string, dataCh chan<- ExchangeState, stop <-chan bool) error {
 	const timeout = 5 * time.Second
+	lastMessage := b.client.now().UnixNano()
 	client := signalr.NewWebsocketClient()
 	client.OnClientMethod = func(hub string, method string, messages []json.RawMessage) {
+		atomic.StoreInt64(&lastMessage, b.client.now().UnixNano())
 		if hub != WS_HUB || method != "updateExchangeState" {
 			return
 		}
 		parseStates(messages, dataCh, market)
 	}
-	err := doAsyncTimeout(func() error {
-		return client.Connect("https", WS_BASE, []string{WS_HUB})
-	}, func(err error) {
-		if err == nil {
-			client.Close()
-		}
-	}, timeout)
+	err := connectHub(client, WS_HUB, timeout)
 	if err != nil {
 		return err
 	}
@@ -119,9 +165,304 @@ func (b *Bittrex) SubscribeExchangeUpdate(market string, dataCh chan<- ExchangeS
 	st.Initial = true
 	st.MarketName = market
 	sendStateAsync(dataCh, st)
-	select {
-	case <-stop:
-	case <-client.DisconnectedChannel:
+	atomic.StoreInt64(&lastMessage, b.client.now().UnixNano())
+
+	var heartbeat <-chan time.Time
+	if b.heartbeatTimeout > 0 {
+		ticker := time.NewTicker(b.heartbeatTimeout / 2)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-client.DisconnectedChannel:
+			return nil
+		case <-heartbeat:
+			if b.client.now().Sub(time.Unix(0, atomic.LoadInt64(&lastMessage))) > b.heartbeatTimeout {
+				return ErrStreamStale
+			}
+		}
+	}
+}
+
+// SubscribeExchangeUpdateManaged subscribes for updates of the market as
+// SubscribeExchangeUpdate does, but reconnects when the stream is disconnected or stale.
+// Each disconnection is reported to errCh, if not nil and ready to receive, and is
+// followed by a new initial state once reconnected.
+// To stop subscription, send to, or close 'stop'.
+func (b *Bittrex) SubscribeExchangeUpdateManaged(market string, dataCh chan<- ExchangeState, errCh chan<- error, stop <-chan bool) {
+	done := make(chan bool)
+	go func() {
+		<-stop
+		close(done)
+	}()
+	for {
+		err := b.SubscribeExchangeUpdate(market, dataCh, done)
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if err == nil {
+			err = ErrStreamDisconnected
+		}
+		if errCh != nil {
+			select {
+			case errCh <- err:
+			default:
+			}
+		}
+		select {
+		case <-done:
+			return
+		case <-time.After(WS_RECONNECT_DELAY):
+		}
+	}
+}
+
+// OrderBookUpdate is a change of the order book of a market, sent by SubscribeOrderBook.
+// Updates of a stream have consecutive nonces, except after a gap.
+type OrderBookUpdate struct {
+	MarketName string
+	Nonce      int
+	// Snapshot is true if Buys and Sells are the full order book, which replaces the
+	// local one. Otherwise they are deltas, whose Type is 0 for a new rate, 1 for a
+	// removed rate and 2 for an updated quantity.
+	Snapshot bool
+	// Gap is true if updates were missed: the local order book is invalid until the
+	// next snapshot, which the stream sends after resubscribing. Buys and Sells are empty.
+	Gap   bool
+	Buys  []OrderUpdate
+	Sells []OrderUpdate
+}
+
+// orderBookSequencer orders the states of an exchange stream into order book updates:
+// deltas received before the initial snapshot are held until it arrives, deltas
+// already included in the book are dropped and missing nonces are reported as a gap.
+type orderBookSequencer struct {
+	synced  bool
+	nonce   int
+	pending []ExchangeState
+}
+
+// next returns the updates to send for state st, and whether a gap was detected.
+func (s *orderBookSequencer) next(st ExchangeState) (updates []OrderBookUpdate, gap bool) {
+	if !st.Initial && !s.synced {
+		s.pending = append(s.pending, st)
+		return nil, false
 	}
-	return nil
+	states := []ExchangeState{st}
+	if st.Initial {
+		s.synced, s.nonce = true, st.Nounce
+		updates = append(updates, newOrderBookUpdate(st))
+		states, s.pending = s.pending, nil
+	}
+	for _, st := range states {
+		if st.Nounce <= s.nonce {
+			continue
+		}
+		if st.Nounce != s.nonce+1 {
+			return append(updates, OrderBookUpdate{MarketName: st.MarketName, Nonce: st.Nounce, Gap: true}), true
+		}
+		s.nonce = st.Nounce
+		updates = append(updates, newOrderBookUpdate(st))
+	}
+	return updates, false
+}
+
+func newOrderBookUpdate(st ExchangeState) OrderBookUpdate {
+	return OrderBookUpdate{MarketName: st.MarketName, Nonce: st.Nounce, Snapshot: st.Initial, Buys: st.Buys, Sells: st.Sells}
+}
+
+// SubscribeOrderBook streams the order book of market: an initial snapshot followed by
+// deltas, in nonce order. When updates are missed (ex: the caller is too slow, or the
+// connection drops), an update with Gap set is sent and the stream resubscribes, which
+// sends a new snapshot. The channel is closed once ctx is done.
+func (b *Bittrex) SubscribeOrderBook(ctx context.Context, market string) (<-chan OrderBookUpdate, error) {
+	if market == "" {
+		return nil, ErrEmptyMarket
+	}
+	market = strings.ToUpper(market)
+	updates := make(chan OrderBookUpdate, WS_BUFFER_SIZE)
+	go func() {
+		defer close(updates)
+		for {
+			b.streamOrderBook(ctx, market, updates)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(WS_RECONNECT_DELAY):
+			}
+		}
+	}()
+	return updates, nil
+}
+
+// streamOrderBook subscribes to the exchange updates of market and sends them to updates,
+// until ctx is done, the connection drops or a gap is detected.
+func (b *Bittrex) streamOrderBook(ctx context.Context, market string, updates chan<- OrderBookUpdate) {
+	states := make(chan ExchangeState, WS_BUFFER_SIZE)
+	stop := make(chan bool)
+	done := make(chan struct{})
+	go func() {
+		b.SubscribeExchangeUpdate(market, states, stop)
+		close(done)
+	}()
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	var sequencer orderBookSequencer
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			if sequencer.synced {
+				// the connection dropped, the next subscription starts with a snapshot
+				select {
+				case updates <- OrderBookUpdate{MarketName: market, Nonce: sequencer.nonce + 1, Gap: true}:
+				case <-ctx.Done():
+				}
+			}
+			return
+		case st := <-states:
+			st.MarketName = market
+			next, gap := sequencer.next(st)
+			for _, update := range next {
+				select {
+				case updates <- update:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if gap {
+				return
+			}
+		}
+	}
+}
+
+// summaryState is a message of the market summaries stream
+type summaryState struct {
+	Nounce int
+	Deltas []MarketSummary
+}
+
+// summaryStream sends the deltas of the summary states to its channel, without blocking
+// the SignalR client: when the channel is full, the delta is dropped and overflow signaled.
+type summaryStream struct {
+	// guards summaries, which the client may still write to while it is being closed
+	mu        sync.Mutex
+	closed    bool
+	summaries chan MarketSummary
+	overflow  chan struct{}
+}
+
+func newSummaryStream() *summaryStream {
+	return &summaryStream{
+		summaries: make(chan MarketSummary, WS_BUFFER_SIZE),
+		overflow:  make(chan struct{}, 1),
+	}
+}
+
+// handle sends the deltas of messages, summary states; undecodable messages are skipped.
+func (s *summaryStream) handle(messages []json.RawMessage) {
+	for _, msg := range messages {
+		var st summaryState
+		if err := json.Unmarshal(msg, &st); err != nil {
+			continue
+		}
+		s.mu.Lock()
+		for _, summary := range st.Deltas {
+			if s.closed {
+				break
+			}
+			select {
+			case s.summaries <- summary:
+			default:
+				select {
+				case s.overflow <- struct{}{}:
+				default:
+				}
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// close closes the channel of summaries, after which handle sends nothing
+func (s *summaryStream) close() {
+	s.mu.Lock()
+	s.closed = true
+	close(s.summaries)
+	s.mu.Unlock()
+}
+
+// SubscribeMarketSummaries streams the summaries of all markets: each one is sent when
+// it changes. When the connection drops (or is stale, see SetHeartbeatTimeout),
+// ErrStreamDisconnected (or ErrStreamStale) is sent to errs, then both channels are
+// closed; call SubscribeMarketSummaries again to reconnect. Summaries are not queued
+// beyond WS_BUFFER_SIZE: if the caller falls behind, ErrStreamOverflow is sent to errs
+// and the channels are closed the same way. They are also closed, without error, once
+// ctx is done. err is returned if the subscription fails.
+func (b *Bittrex) SubscribeMarketSummaries(ctx context.Context) (summaries <-chan MarketSummary, errs <-chan error, err error) {
+	const timeout = 5 * time.Second
+	stream := newSummaryStream()
+	errCh := make(chan error, 1)
+	lastMessage := b.client.now().UnixNano()
+	client := signalr.NewWebsocketClient()
+	client.OnClientMethod = func(hub string, method string, messages []json.RawMessage) {
+		atomic.StoreInt64(&lastMessage, b.client.now().UnixNano())
+		if hub != WS_HUB || method != "updateSummaryState" {
+			return
+		}
+		stream.handle(messages)
+	}
+	if err = connectHub(client, WS_HUB, timeout); err != nil {
+		return
+	}
+	err = doAsyncTimeout(func() error {
+		_, err := client.CallHub(WS_HUB, "SubscribeToSummaryDeltas")
+		return err
+	}, nil, timeout)
+	if err != nil {
+		client.Close()
+		return
+	}
+
+	go func() {
+		defer close(errCh)
+		defer func() {
+			client.Close()
+			stream.close()
+		}()
+		var heartbeat <-chan time.Time
+		if b.heartbeatTimeout > 0 {
+			ticker := time.NewTicker(b.heartbeatTimeout / 2)
+			defer ticker.Stop()
+			heartbeat = ticker.C
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-client.DisconnectedChannel:
+				errCh <- ErrStreamDisconnected
+				return
+			case <-stream.overflow:
+				errCh <- ErrStreamOverflow
+				return
+			case <-heartbeat:
+				if b.client.now().Sub(time.Unix(0, atomic.LoadInt64(&lastMessage))) > b.heartbeatTimeout {
+					errCh <- ErrStreamStale
+					return
+				}
+			}
+		}
+	}()
+	return stream.summaries, errCh, nil
 }
@@ -0,0 +1,224 @@
+package bittrex
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thebotguys/signalr"
+)
+
+// WS_AUTH_HUB is the SignalR hub of the authenticated streams
+const WS_AUTH_HUB = "c2"
+
+// orderDelta is a message of the authenticated order stream, whose fields Bittrex
+// abbreviates. Times are in milliseconds since epoch.
+type orderDelta struct {
+	Nonce int `json:"N"`
+	Type  int `json:"TY"` // 0 open, 1 partial fill, 2 fill, 3 cancel
+	Order struct {
+		OrderUuid         string           `json:"OU"`
+		Exchange          string           `json:"E"`
+		OrderType         string           `json:"OT"`
+		Quantity          decimal.Decimal  `json:"Q"`
+		QuantityRemaining decimal.Decimal  `json:"q"`
+		Limit             *decimal.Decimal `json:"X"`
+		CommissionPaid    decimal.Decimal  `json:"n"`
+		Price             decimal.Decimal  `json:"P"`
+		PricePerUnit      *decimal.Decimal `json:"PU"`
+		Opened            int64            `json:"Y"`
+		Closed            *int64           `json:"C"`
+	} `json:"o"`
+}
+
+// order returns the order of delta
+func (delta orderDelta) order() Order {
+	o := delta.Order
+	order := Order{
+		OrderUuid:         o.OrderUuid,
+		Exchange:          o.Exchange,
+		TimeStamp:         jTime{time.Unix(0, o.Opened*int64(time.Millisecond)).UTC()},
+		OrderType:         o.OrderType,
+		Limit:             o.Limit,
+		Quantity:          o.Quantity,
+		QuantityRemaining: o.QuantityRemaining,
+		Commission:        o.CommissionPaid,
+		Price:             o.Price,
+		PricePerUnit:      o.PricePerUnit,
+	}
+	if o.Closed != nil {
+		order.Closed = &jTime{time.Unix(0, *o.Closed*int64(time.Millisecond)).UTC()}
+	}
+	return order
+}
+
+// decodeCompressed unmarshals into v a message of WS_AUTH_HUB: a JSON string holding
+// the base64 encoding of the deflated JSON value.
+func decodeCompressed(msg json.RawMessage, v interface{}) error {
+	var encoded string
+	if err := json.Unmarshal(msg, &encoded); err != nil {
+		return err
+	}
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// authenticateHub authenticates the connection of client to WS_AUTH_HUB: the challenge
+// returned for the API key is signed with the API secret (see Sign).
+func (b *Bittrex) authenticateHub(client *signalr.Client) error {
+	r, err := client.CallHub(WS_AUTH_HUB, "GetAuthContext", b.client.apiKey)
+	if err != nil {
+		return err
+	}
+	var challenge string
+	if err = json.Unmarshal(r, &challenge); err != nil {
+		return fmt.Errorf("could not read authentication challenge: %v", err)
+	}
+	r, err = client.CallHub(WS_AUTH_HUB, "Authenticate", b.client.apiKey, Sign(b.client.apiSecret, challenge))
+	if err != nil {
+		return err
+	}
+	var ok bool
+	if json.Unmarshal(r, &ok) != nil || !ok {
+		return ErrStreamAuthRejected
+	}
+	return nil
+}
+
+// SubscribeOrders streams the changes of your orders (placed, partially filled, filled or
+// canceled), as pushed by Bittrex, without polling GetOrder. An order is closed once its
+// Closed time is set; see Order.IsFilled to tell a fill from a cancel. The stream is
+// authenticated with the API credentials, and again when Bittrex asks for it.
+// When the connection drops, or the stream fails to authenticate again, the stream
+// reconnects after WS_RECONNECT_DELAY; changes made meanwhile are not sent, compare with
+// GetOpenOrders if needed. Changes are never dropped: once WS_BUFFER_SIZE of them are
+// waiting, the stream stops reading the connection until the caller receives one, so a
+// slow caller delays the changes and may get the connection dropped by Bittrex. The
+// channel is closed once ctx is done, or if Bittrex rejects the authentication when
+// reconnecting (ex: revoked API key). err is returned if the first connection fails,
+// ErrStreamAuthRejected if it is rejected.
+func (b *Bittrex) SubscribeOrders(ctx context.Context) (<-chan Order, error) {
+	if b.client.apiKey == "" || b.client.apiSecret == "" {
+		return nil, ErrAuthNotConfigured
+	}
+	orders := make(chan Order, WS_BUFFER_SIZE)
+	// guards orders, which a client may still write to while it is being closed
+	var mu sync.Mutex
+	closed := false
+	// send blocks the SignalR client while orders is full; holding mu meanwhile is safe as
+	// orders is only closed once ctx is done, which releases send.
+	send := func(order Order) {
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case orders <- order:
+		case <-ctx.Done():
+		}
+	}
+
+	// receives the clients which failed to authenticate again, to reconnect
+	authFailed := make(chan *signalr.Client, 1)
+	onAuthError := func(client *signalr.Client) {
+		select {
+		case authFailed <- client:
+		default:
+		}
+	}
+
+	client, err := b.connectOrders(send, onAuthError)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer func() {
+			mu.Lock()
+			closed = true
+			close(orders)
+			mu.Unlock()
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				client.Close()
+				return
+			case <-client.DisconnectedChannel:
+			case failed := <-authFailed:
+				if failed != client {
+					// a client already replaced
+					continue
+				}
+			}
+			client.Close()
+			for client = nil; client == nil; {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(WS_RECONNECT_DELAY):
+				}
+				// the other errors (ex: network) are retried
+				if client, err = b.connectOrders(send, onAuthError); errors.Is(err, ErrStreamAuthRejected) {
+					return
+				}
+			}
+		}
+	}()
+	return orders, nil
+}
+
+// connectOrders connects to WS_AUTH_HUB, authenticates and passes the orders received to send.
+// If authenticating again, when Bittrex asks for it, fails, the client is passed to onAuthError.
+func (b *Bittrex) connectOrders(send func(Order), onAuthError func(*signalr.Client)) (*signalr.Client, error) {
+	const timeout = 5 * time.Second
+	client := signalr.NewWebsocketClient()
+	client.OnClientMethod = func(hub string, method string, messages []json.RawMessage) {
+		if hub != WS_AUTH_HUB {
+			return
+		}
+		switch method {
+		case "authenticationExpiring":
+			// calling the hub from its callback would block the connection
+			go func() {
+				if err := b.authenticateHub(client); err != nil {
+					onAuthError(client)
+				}
+			}()
+		case "uO":
+			for _, msg := range messages {
+				var delta orderDelta
+				if err := decodeCompressed(msg, &delta); err != nil {
+					continue
+				}
+				send(delta.order())
+			}
+		}
+	}
+	if err := connectHub(client, WS_AUTH_HUB, timeout); err != nil {
+		return nil, err
+	}
+	err := doAsyncTimeout(func() error {
+		return b.authenticateHub(client)
+	}, nil, timeout)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
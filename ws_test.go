@@ -1,7 +1,13 @@
 package bittrex
 
 import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 )
@@ -38,3 +44,111 @@ func TestBittrexSubscribeOrderBook(t *testing.T) {
 		}
 	}
 }
+
+func TestOrderBookSequencer(t *testing.T) {
+	var s orderBookSequencer
+	check := func(st ExchangeState, expectedNonces []int, expectedGap bool) {
+		t.Helper()
+		updates, gap := s.next(st)
+		var nonces []int
+		for _, update := range updates {
+			nonces = append(nonces, update.Nonce)
+		}
+		if fmt.Sprint(nonces) != fmt.Sprint(expectedNonces) || gap != expectedGap {
+			t.Errorf("nonce %d: expected %v (gap %v), got %v (gap %v)", st.Nounce, expectedNonces, expectedGap, nonces, gap)
+		}
+	}
+	// deltas received before the snapshot are held, then those not in it are sent
+	check(ExchangeState{Nounce: 10}, nil, false)
+	check(ExchangeState{Nounce: 11}, nil, false)
+	check(ExchangeState{Nounce: 10, Initial: true}, []int{10, 11}, false)
+	check(ExchangeState{Nounce: 12}, []int{12}, false)
+	check(ExchangeState{Nounce: 12}, nil, false)
+	check(ExchangeState{Nounce: 14}, []int{14}, true)
+}
+
+func TestSummaryStream(t *testing.T) {
+	s := newSummaryStream()
+	s.handle([]json.RawMessage{
+		json.RawMessage(`{"Nounce":1,"Deltas":[{"MarketName":"BTC-LTC"},{"MarketName":"BTC-ETH"}]}`),
+		json.RawMessage(`"not a summary state"`),
+		json.RawMessage(`{"Nounce":2,"Deltas":[{"MarketName":"BTC-LTC"}]}`),
+	})
+	var names []string
+	for len(s.summaries) > 0 {
+		names = append(names, (<-s.summaries).MarketName)
+	}
+	if fmt.Sprint(names) != "[BTC-LTC BTC-ETH BTC-LTC]" {
+		t.Errorf("unexpected summaries %v", names)
+	}
+	select {
+	case <-s.overflow:
+		t.Error("unexpected overflow")
+	default:
+	}
+
+	// a full channel drops the delta, without blocking, and signals the overflow
+	deltas := make([]MarketSummary, WS_BUFFER_SIZE+1)
+	st, _ := json.Marshal(summaryState{Nounce: 3, Deltas: deltas})
+	s.handle([]json.RawMessage{st})
+	if len(s.summaries) != WS_BUFFER_SIZE {
+		t.Errorf("expected a full channel, got %d summaries", len(s.summaries))
+	}
+	select {
+	case <-s.overflow:
+	default:
+		t.Error("expected an overflow")
+	}
+
+	// nothing is sent once closed
+	s.close()
+	s.handle([]json.RawMessage{st})
+	for range s.summaries {
+	}
+}
+
+func TestSetHeartbeatTimeout(t *testing.T) {
+	bt := New("", "")
+	for _, c := range []struct{ timeout, expected time.Duration }{
+		{time.Minute, time.Minute},
+		{time.Nanosecond, MIN_HEARTBEAT_TIMEOUT},
+		{0, 0},
+		{-time.Second, 0},
+	} {
+		bt.SetHeartbeatTimeout(c.timeout)
+		if bt.heartbeatTimeout != c.expected {
+			t.Errorf("SetHeartbeatTimeout(%s): expected %s, got %s", c.timeout, c.expected, bt.heartbeatTimeout)
+		}
+	}
+}
+
+func TestDecodeOrderDelta(t *testing.T) {
+	delta := `{"w":"account","N":7,"TY":3,"o":{"U":"u","I":1,"OU":"abc","E":"BTC-LTC","OT":"LIMIT_BUY",
+		"Q":2,"q":1.5,"X":0.01,"n":0.00001,"P":0.005,"PU":0.01,"Y":1529617000000,"C":1529618000000}}`
+	var compressed bytes.Buffer
+	w, _ := flate.NewWriter(&compressed, flate.BestCompression)
+	w.Write([]byte(delta))
+	w.Close()
+	msg, _ := json.Marshal(base64.StdEncoding.EncodeToString(compressed.Bytes()))
+
+	var d orderDelta
+	if err := decodeCompressed(msg, &d); err != nil {
+		t.Fatal(err)
+	}
+	order := d.order()
+	if d.Type != 3 || order.OrderUuid != "abc" || order.Exchange != "BTC-LTC" || order.FilledQuantity().String() != "0.5" {
+		t.Errorf("unexpected order %+v", order)
+	}
+	if order.Closed == nil || !order.Closed.Equal(time.Unix(1529618000, 0)) || order.IsFilled() {
+		t.Errorf("expected an order canceled at 1529618000, got %+v", order)
+	}
+	if order.Limit == nil || order.Limit.String() != "0.01" {
+		t.Errorf("unexpected limit %v", order.Limit)
+	}
+}
+
+func TestSubscribeOrdersAuthNotConfigured(t *testing.T) {
+	if _, err := New("", "").SubscribeOrders(context.Background()); err != ErrAuthNotConfigured {
+		t.Errorf("expected ErrAuthNotConfigured, got %v", err)
+	}
+}